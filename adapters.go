@@ -1,9 +1,10 @@
 package integrations
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log/slog"
+	"mime"
 	"strconv"
 	"strings"
 
@@ -11,12 +12,168 @@ import (
 	"github.com/nao1215/markdown"
 )
 
-// func generic(input *Input) *Webhook {
-// 	return &Webhook{
-// 		Data:    input.Message,
-// 		Headers: nil,
-// 	}
-// }
+// GenericSchemaVersion is the version of the canonical JSON document emitted by
+// the generic adapter. It is bumped whenever a field is removed or changes meaning.
+const GenericSchemaVersion = "1.0"
+
+// GenericPayload is the canonical JSON document of the generic adapter. Its
+// types are independent of the input structs, so the schema only changes
+// together with GenericSchemaVersion. Lists are never null.
+type GenericPayload struct {
+	// SchemaVersion is GenericSchemaVersion.
+	SchemaVersion string `json:"schemaVersion"`
+	// Event is the type of the event, e.g. "form.finished".
+	Event EventType   `json:"event"`
+	Form  GenericForm `json:"form"`
+	// Contact is the contact collected for the whole form, if any.
+	Contact *GenericContact `json:"contact,omitempty"`
+	// Nodes are the answered nodes in the order of the form.
+	Nodes []GenericNode `json:"nodes"`
+}
+
+type GenericForm struct {
+	// Title is the title of the notification.
+	Title string `json:"title"`
+	// Name is the translated name of the form.
+	Name string `json:"name"`
+	// LinkText and LinkUrl point to the submission.
+	LinkText string `json:"linkText"`
+	LinkUrl  string `json:"linkUrl"`
+}
+
+// GenericNode holds exactly one of Choice, Select, Contact or Rating,
+// depending on Type.
+type GenericNode struct {
+	// Relation identifies the node within the form and stays the same
+	// across submissions.
+	Relation int64 `json:"relation"`
+	// Type is one of "choice", "select", "contact" or "rating".
+	Type string `json:"type"`
+	// Label is the translated question of the node.
+	Label   string          `json:"label"`
+	Choice  *GenericChoice  `json:"choice,omitempty"`
+	Select  *GenericSelect  `json:"select,omitempty"`
+	Contact *GenericContact `json:"contact,omitempty"`
+	Rating  *GenericRating  `json:"rating,omitempty"`
+}
+
+// GenericChoice lists the chosen elements with the answers given to them.
+type GenericChoice struct {
+	Elements []GenericChoiceElement `json:"elements"`
+}
+
+type GenericChoiceElement struct {
+	Label string `json:"label"`
+	// AnswerShort and AnswerLong are the free text answers, empty if
+	// there are none.
+	AnswerShort string `json:"answerShort"`
+	AnswerLong  string `json:"answerLong"`
+}
+
+// GenericSelect lists the selected options.
+type GenericSelect struct {
+	Label    string   `json:"label"`
+	Selected []string `json:"selected"`
+}
+
+// GenericContact holds the contact details of the respondent, empty fields
+// were not filled in.
+type GenericContact struct {
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Email     string `json:"email"`
+	Company   string `json:"company"`
+	Phone     string `json:"phone"`
+	Details   string `json:"details"`
+}
+
+// GenericRating lists the rated elements.
+type GenericRating struct {
+	Label    string                 `json:"label"`
+	Elements []GenericRatingElement `json:"elements"`
+}
+
+type GenericRatingElement struct {
+	Label string `json:"label"`
+	// Value ranges from 0 to 10.
+	Value int64 `json:"value"`
+}
+
+func genericContact(contact *InputContactNode) *GenericContact {
+	return &GenericContact{
+		Firstname: contact.Firstname,
+		Lastname:  contact.Lastname,
+		Email:     contact.Email,
+		Company:   contact.Company,
+		Phone:     contact.Phone,
+		Details:   contact.Details,
+	}
+}
+
+func generic(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	payload := GenericPayload{
+		SchemaVersion: GenericSchemaVersion,
+		Event:         data.EventType(),
+		Form: GenericForm{
+			Title:    data.Title,
+			Name:     data.FormTranslation,
+			LinkText: data.LinkText,
+			LinkUrl:  data.LinkUrl,
+		},
+		Nodes: []GenericNode{},
+	}
+	if data.Contact != nil {
+		payload.Contact = genericContact(data.Contact)
+	}
+
+	for _, node := range data.Nodes {
+		genericNode := GenericNode{
+			Relation: node.Relation,
+			Label:    node.NodeTranslation,
+		}
+		switch node.NodeType {
+		case 0:
+			choice := &GenericChoice{Elements: []GenericChoiceElement{}}
+			for _, element := range node.ChoiceNode.Elements {
+				choice.Elements = append(choice.Elements, GenericChoiceElement{
+					Label:       element.Label,
+					AnswerShort: element.AnswerShort,
+					AnswerLong:  element.AnswerLong,
+				})
+			}
+			genericNode.Type = "choice"
+			genericNode.Choice = choice
+		case 1:
+			genericNode.Type = "select"
+			genericNode.Select = &GenericSelect{
+				Label:    node.SelectNode.Label,
+				Selected: append([]string{}, node.SelectNode.Selected...),
+			}
+		case 2:
+			genericNode.Type = "contact"
+			genericNode.Contact = genericContact(&node.ContactNode)
+		case 3:
+			rating := &GenericRating{Label: node.RatingNode.Label, Elements: []GenericRatingElement{}}
+			for _, element := range node.RatingNode.Elements {
+				rating.Elements = append(rating.Elements, GenericRatingElement{
+					Label: element.Label,
+					Value: element.Value,
+				})
+			}
+			genericNode.Type = "rating"
+			genericNode.Rating = rating
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "generic")
+			continue
+		}
+		payload.Nodes = append(payload.Nodes, genericNode)
+	}
+
+	return &Webhook{
+		Data:    payload,
+		Headers: nil,
+	}, nil
+}
 
 type mattermostData struct {
 	Text        string `json:"text"`
@@ -26,101 +183,120 @@ type mattermostData struct {
 	} `json:"attachments"`
 }
 
-func mattermost(input interface{}, eventType EventType) (*Webhook, error) {
-	if input == nil {
-		return nil, errors.New("input undefined")
-	}
-	switch eventType {
-	case EventFormFinished:
-		if data, ok := input.(*InputFormFinished); ok {
-			message := &strings.Builder{}
-			md := markdown.NewMarkdown(message).
-				H2(data.FormTranslation).
-				PlainText("\n")
-
-			if data.Contact != nil {
-				// TODO i18n
-				md.BulletList(
-					"**First name**: "+data.Contact.Firstname,
-					"**Last name**: "+data.Contact.Lastname,
-					"**Email address**: "+data.Contact.Email,
-					"**Company**: "+data.Contact.Company,
-					"**Phone**: "+data.Contact.Phone,
-					"**Details**: "+data.Contact.Details,
-				).
-					PlainText("\n")
-			}
+// contactField is a field of a contact node with its display label and
+// json name.
+type contactField struct {
+	Label string
+	Name  string
+	Value string
+}
 
-			for _, node := range data.Nodes {
-				md.H3(node.NodeTranslation)
-				switch node.NodeType {
-				case 0:
-					for _, element := range node.ChoiceNode.Elements {
-						md.BulletList(element.Label)
-						if element.AnswerShort != "" {
-							md.BlueBadge(element.AnswerShort)
-						}
-						if element.AnswerLong != "" {
-							md.BlueBadge(element.AnswerLong)
-						}
-					}
-					md.PlainText("\n")
-				case 1:
-					md.H4(node.SelectNode.Label).
-						BulletList(node.SelectNode.Selected...).PlainText("\n")
-				case 2:
-					md.BulletList(
-						"**First name**: "+node.ContactNode.Firstname,
-						"**Last name**: "+node.ContactNode.Lastname,
-						"**Email address**: "+node.ContactNode.Email,
-						"**Company**: "+node.ContactNode.Company,
-						"**Phone**: "+node.ContactNode.Phone,
-						"**Details**: "+node.ContactNode.Details,
-					).PlainText("\n")
-				case 3:
-					md.H4(node.RatingNode.Label)
-					rows := [][]string{}
-					for _, element := range node.RatingNode.Elements {
-						rows = append(rows, []string{
-							element.Label,
-							strconv.FormatInt(element.Value, 10) + "/10 :star:",
-						})
-					}
-					md.Table(markdown.TableSet{
-						Header: []string{"Label", "Rating"},
-						Rows:   rows,
-					})
-				default:
-					slog.Warn("unknown node type", "nodeType", node.NodeType, "adapter", "mattermost")
-					continue
+// contactFields returns every field of contact in display order, empty ones
+// included.
+func contactFields(contact *InputContactNode) []contactField {
+	// TODO i18n
+	return []contactField{
+		{"First name", "firstname", contact.Firstname},
+		{"Last name", "lastname", contact.Lastname},
+		{"Email address", "email", contact.Email},
+		{"Company", "company", contact.Company},
+		{"Phone", "phone", contact.Phone},
+		{"Details", "details", contact.Details},
+	}
+}
+
+// filledContactFields returns the fields of contact that are not empty.
+func filledContactFields(contact *InputContactNode) []contactField {
+	fields := []contactField{}
+	for _, field := range contactFields(contact) {
+		if field.Value != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func markdownContactList(md *markdown.Markdown, contact *InputContactNode) *markdown.Markdown {
+	items := []string{}
+	for _, field := range contactFields(contact) {
+		items = append(items, "**"+field.Label+"**: "+field.Value)
+	}
+	return md.BulletList(items...)
+}
+
+// markdownMessage renders the form name, contact and nodes of data as markdown,
+// shared by all adapters whose platform accepts markdown message bodies.
+func markdownMessage(ctx context.Context, data *InputFormFinished, adapter string) string {
+	message := &strings.Builder{}
+	md := markdown.NewMarkdown(message).
+		H2(data.FormTranslation).
+		PlainText("\n")
+
+	if data.Contact != nil {
+		markdownContactList(md, data.Contact).
+			PlainText("\n")
+	}
+
+	for _, node := range data.Nodes {
+		md.H3(node.NodeTranslation)
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				md.BulletList(element.Label)
+				if element.AnswerShort != "" {
+					md.BlueBadge(element.AnswerShort)
+				}
+				if element.AnswerLong != "" {
+					md.BlueBadge(element.AnswerLong)
 				}
-				md.PlainText("\n")
 			}
+			md.PlainText("\n")
+		case 1:
+			md.H4(node.SelectNode.Label).
+				BulletList(node.SelectNode.Selected...).PlainText("\n")
+		case 2:
+			markdownContactList(md, &node.ContactNode).PlainText("\n")
+		case 3:
+			md.H4(node.RatingNode.Label)
+			rows := [][]string{}
+			for _, element := range node.RatingNode.Elements {
+				rows = append(rows, []string{
+					element.Label,
+					strconv.FormatInt(element.Value, 10) + "/10 :star:",
+				})
+			}
+			md.Table(markdown.TableSet{
+				Header: []string{"Label", "Rating"},
+				Rows:   rows,
+			})
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", adapter)
+			continue
+		}
+		md.PlainText("\n")
+	}
 
-			md.Build()
+	md.Build()
 
-			return &Webhook{
-				Data: mattermostData{
-					Text: fmt.Sprintf("%s [%s](%s)", data.Title, data.LinkText, data.LinkUrl),
-					Attachments: []struct {
-						Text  string `json:"text"`
-						Color string `json:"color"`
-					}{
-						{
-							Color: "#1B5495",
-							Text:  message.String(),
-						},
-					},
+	return message.String()
+}
+
+func mattermost(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	return &Webhook{
+		Data: mattermostData{
+			Text: fmt.Sprintf("%s [%s](%s)", data.Title, data.LinkText, data.LinkUrl),
+			Attachments: []struct {
+				Text  string `json:"text"`
+				Color string `json:"color"`
+			}{
+				{
+					Color: "#1B5495",
+					Text:  markdownMessage(ctx, data, "mattermost"),
 				},
-				Headers: nil,
-			}, nil
-		} else {
-			return nil, errors.New("type assertion failed for InputFormFinished")
-		}
-	default:
-		slog.Warn("unknown event type", "eventType", eventType, "adapter", "mattermost")
-		return nil, errors.New("unknown event type")
-	}
+			},
+		},
+		Headers: nil,
+	}, nil
 }
 
 type slackData struct {
@@ -340,142 +516,206 @@ func slackContactBlock(title string, contact *InputContactNode) slackMessageBloc
 	return contactBlock
 }
 
-func slack(input interface{}, eventType EventType) (*Webhook, error) {
-	if input == nil {
-		return nil, errors.New("input undefined")
+func slack(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	blocks := []slackMessageBlock{
+		{
+			Type: slackMessageBlockTypeSection,
+			Text: &slackMessageBlockText{
+				Type: slackMessageBlockTextTypeMarkdown,
+				Text: fmt.Sprintf("%s <%s|%s>", data.Title, data.LinkUrl, data.LinkText),
+			},
+		},
+		{
+			Type: slackMessageBlockTypeDivider,
+		},
+	}
+
+	if data.Contact != nil {
+		blocks = append(blocks, slackContactBlock("", data.Contact))
 	}
-	switch eventType {
-	case EventFormFinished:
-		if data, ok := input.(*InputFormFinished); ok {
-			blocks := []slackMessageBlock{
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		switch node.NodeType {
+		case 0:
+			richTextElements := []slackMessageBlockText{
 				{
-					Type: slackMessageBlockTypeSection,
-					Text: &slackMessageBlockText{
-						Type: slackMessageBlockTextTypeMarkdown,
-						Text: fmt.Sprintf("%s <%s|%s>", data.Title, data.LinkUrl, data.LinkText),
+					Type: slackMessageBlockTextTypeRichTextSection,
+					Elements: &[]slackMessageBlockText{
+						{
+							Type: slackMessageBlockTextTypeText,
+							Text: node.NodeTranslation,
+						},
 					},
 				},
-				{
-					Type: slackMessageBlockTypeDivider,
-				},
-			}
-
-			if data.Contact != nil {
-				blocks = append(blocks, slackContactBlock("", data.Contact))
 			}
 
-			for _, node := range data.Nodes {
-				if node.NodeTranslation == "" {
-					node.NodeTranslation = "Missing Translation"
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
 				}
-				switch node.NodeType {
-				case 0:
-					richTextElements := []slackMessageBlockText{
+				richTextElements = append(richTextElements, slackMessageBlockText{
+					Type:  slackMessageBlockTextTypeRichTextList,
+					Style: slackMessageBlockElementStyleBullet,
+					Elements: &[]slackMessageBlockText{
 						{
 							Type: slackMessageBlockTextTypeRichTextSection,
 							Elements: &[]slackMessageBlockText{
 								{
 									Type: slackMessageBlockTextTypeText,
-									Text: node.NodeTranslation,
+									Text: element.Label,
 								},
 							},
 						},
-					}
-
-					for _, element := range node.ChoiceNode.Elements {
-						if element.Label == "" {
-							continue
-						}
-						richTextElements = append(richTextElements, slackMessageBlockText{
-							Type:  slackMessageBlockTextTypeRichTextList,
-							Style: slackMessageBlockElementStyleBullet,
-							Elements: &[]slackMessageBlockText{
-								{
-									Type: slackMessageBlockTextTypeRichTextSection,
-									Elements: &[]slackMessageBlockText{
-										{
-											Type: slackMessageBlockTextTypeText,
-											Text: element.Label,
-										},
-									},
-								},
+					},
+				})
+				if element.AnswerShort != "" {
+					richTextElements = append(richTextElements, slackMessageBlockText{
+						Type: slackMessageBlockTextTypeRichTextPreformatted,
+						Elements: &[]slackMessageBlockText{
+							{
+								Type: slackMessageBlockTextTypeText,
+								Text: element.AnswerShort,
 							},
-						})
-						if element.AnswerShort != "" {
-							richTextElements = append(richTextElements, slackMessageBlockText{
-								Type: slackMessageBlockTextTypeRichTextPreformatted,
-								Elements: &[]slackMessageBlockText{
-									{
-										Type: slackMessageBlockTextTypeText,
-										Text: element.AnswerShort,
-									},
-								},
-							})
-						}
-						if element.AnswerLong != "" {
-							richTextElements = append(richTextElements, slackMessageBlockText{
-								Type: slackMessageBlockTextTypeRichTextPreformatted,
-								Elements: &[]slackMessageBlockText{
-									{
-										Type: slackMessageBlockTextTypeText,
-										Text: element.AnswerLong,
-									},
-								},
-							})
-						}
-					}
-
-					richTextBlock := slackMessageBlock{
-						Type:     slackMessageBlockTypeRichText,
-						Elements: &richTextElements,
-					}
-					blocks = append(blocks, richTextBlock)
-				case 1:
-					blocks = append(blocks, slackBlockBulletList(node.SelectNode.Label, node.SelectNode.Selected))
-				case 2:
-					blocks = append(blocks, slackContactBlock(node.NodeTranslation, &node.ContactNode))
-				case 3:
-					rows := make([]string, len(node.RatingNode.Elements))
-					for idx, element := range node.RatingNode.Elements {
-						rows[idx] = fmt.Sprint(element.Label, ": ", strconv.FormatInt(element.Value, 10), "/10 ⭐")
-					}
-					blocks = append(blocks, slackBlockBulletList(node.RatingNode.Label, rows))
-				default:
-					slog.Warn("unknown node type", "nodeType", node.NodeType, "adapter", "slack")
-					continue
+						},
+					})
+				}
+				if element.AnswerLong != "" {
+					richTextElements = append(richTextElements, slackMessageBlockText{
+						Type: slackMessageBlockTextTypeRichTextPreformatted,
+						Elements: &[]slackMessageBlockText{
+							{
+								Type: slackMessageBlockTextTypeText,
+								Text: element.AnswerLong,
+							},
+						},
+					})
 				}
-				blocks = append(blocks, slackMessageBlock{
-					Type: slackMessageBlockTypeDivider,
-				})
 			}
 
-			return &Webhook{
-				Data: slackData{
-					Blocks: blocks,
-				},
-				Headers: nil,
-			}, nil
-		} else {
-			return nil, errors.New("type assertion failed for InputFormFinished")
+			richTextBlock := slackMessageBlock{
+				Type:     slackMessageBlockTypeRichText,
+				Elements: &richTextElements,
+			}
+			blocks = append(blocks, richTextBlock)
+		case 1:
+			blocks = append(blocks, slackBlockBulletList(node.SelectNode.Label, node.SelectNode.Selected))
+		case 2:
+			blocks = append(blocks, slackContactBlock(node.NodeTranslation, &node.ContactNode))
+		case 3:
+			rows := make([]string, len(node.RatingNode.Elements))
+			for idx, element := range node.RatingNode.Elements {
+				rows[idx] = fmt.Sprint(element.Label, ": ", strconv.FormatInt(element.Value, 10), "/10 ⭐")
+			}
+			blocks = append(blocks, slackBlockBulletList(node.RatingNode.Label, rows))
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "slack")
+			continue
 		}
-	default:
-		slog.Warn("unknown event type", "eventType", eventType, "adapter", "slack")
-		return nil, errors.New("unknown event type")
+		blocks = append(blocks, slackMessageBlock{
+			Type: slackMessageBlockTypeDivider,
+		})
+	}
+
+	return &Webhook{
+		Data: slackData{
+			Blocks: blocks,
+		},
+		Headers: nil,
+	}, nil
+}
+
+func ntfyContactLines(message *strings.Builder, contact *InputContactNode) {
+	for _, field := range filledContactFields(contact) {
+		fmt.Fprintf(message, "- **%s**: %s\n", field.Label, field.Value)
 	}
 }
 
-// func ntfy(input interface{}, eventType EventType) (*Webhook, error) {
-// 	switch eventType {
-// 	case EventFormFinished:
-// 		return &Webhook{
-// 			Data: input.Message,
-// 			Headers: map[string][]string{
-// 				"X-Title": {input.Title},
-// 			},
-// 		}, nil
-// 	}
-// 	return nil, errors.New("unknown event type")
-// }
+// NtfyConfig configures the Ntfy adapter. Tags default to "memo", Priority
+// ranges from 1 (min) to 5 (max), zero leaves the default of the topic.
+type NtfyConfig struct {
+	Tags     []string
+	Priority int
+}
+
+func ntfy(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, _ := config.(*NtfyConfig)
+	if cfg == nil {
+		cfg = &NtfyConfig{}
+	}
+	if cfg.Priority < 0 || cfg.Priority > 5 {
+		return nil, errors.New("ntfy priority out of range")
+	}
+
+	message := &strings.Builder{}
+	if data.FormTranslation != "" {
+		fmt.Fprintf(message, "**%s**\n\n", data.FormTranslation)
+	}
+
+	if data.Contact != nil {
+		ntfyContactLines(message, data.Contact)
+		message.WriteString("\n")
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		switch node.NodeType {
+		case 0:
+			fmt.Fprintf(message, "**%s**\n", node.NodeTranslation)
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				fmt.Fprintf(message, "- %s\n", element.Label)
+				if element.AnswerShort != "" {
+					fmt.Fprintf(message, "  %s\n", element.AnswerShort)
+				}
+				if element.AnswerLong != "" {
+					fmt.Fprintf(message, "  %s\n", element.AnswerLong)
+				}
+			}
+		case 1:
+			fmt.Fprintf(message, "**%s**: %s\n", node.NodeTranslation, strings.Join(node.SelectNode.Selected, ", "))
+		case 2:
+			fmt.Fprintf(message, "**%s**\n", node.NodeTranslation)
+			ntfyContactLines(message, &node.ContactNode)
+		case 3:
+			fmt.Fprintf(message, "**%s**\n", node.NodeTranslation)
+			for _, element := range node.RatingNode.Elements {
+				fmt.Fprintf(message, "- %s: %d/10 ⭐\n", element.Label, element.Value)
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "ntfy")
+			continue
+		}
+		message.WriteString("\n")
+	}
+
+	headers := map[string][]string{
+		// ntfy accepts RFC 2047 encoded headers for non-ASCII titles
+		"X-Title":    {mime.QEncoding.Encode("utf-8", data.Title)},
+		"X-Tags":     {"memo"},
+		"X-Markdown": {"yes"},
+	}
+	if len(cfg.Tags) > 0 {
+		headers["X-Tags"] = []string{strings.Join(cfg.Tags, ",")}
+	}
+	if cfg.Priority != 0 {
+		headers["X-Priority"] = []string{strconv.Itoa(cfg.Priority)}
+	}
+	if data.LinkUrl != "" {
+		headers["X-Click"] = []string{data.LinkUrl}
+	}
+
+	return &Webhook{
+		Data:    strings.TrimSpace(message.String()),
+		Headers: headers,
+	}, nil
+}
 
 type teamsData struct {
 	Type        string                `json:"type"`
@@ -488,200 +728,232 @@ type teamsDataAttachment struct {
 	// ContentUrl string `json:"contentUrl"`
 }
 
-func teams(input interface{}, eventType EventType) (*Webhook, error) {
-	if input == nil {
-		return nil, errors.New("input undefined")
+// teamsCard renders data as the adaptive card shared by the Teams and Webex
+// adapters. Schema and Version are left to the caller.
+func teamsCard(ctx context.Context, data *InputFormFinished, adapter string) *adaptivecards.AdaptiveCard {
+	card := adaptivecards.NewAdaptiveCard()
+	card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+		Type:      adaptivecards.ElementTypeTextBlock,
+		Text:      data.Title,
+		Size:      adaptivecards.FontSizeExtraLarge,
+		IsVisible: true,
+	})
+	card.Actions = []adaptivecards.Action{
+		adaptivecards.NewActionOpenUrl(data.LinkUrl, data.LinkText),
 	}
-	switch eventType {
-	case EventFormFinished:
-		card := adaptivecards.NewAdaptiveCard()
-		if data, ok := input.(*InputFormFinished); ok {
-			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-				Type:      adaptivecards.ElementTypeTextBlock,
-				Text:      data.Title,
-				Size:      adaptivecards.FontSizeExtraLarge,
-				IsVisible: true,
-			})
-			card.Actions = []adaptivecards.Action{
-				adaptivecards.NewActionOpenUrl(data.LinkUrl, data.LinkText),
-			}
 
-			card.Schema = "" // $ sign in $schema struct tag trips convoy up
+	if data.Contact != nil {
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeMedium,
+			Text:      fmt.Sprint("**First name**: ", data.Contact.Firstname),
+			IsVisible: true,
+			Separator: true,
+		})
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeMedium,
+			Text:      fmt.Sprint("**Last name**: ", data.Contact.Lastname),
+			IsVisible: true,
+		})
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeMedium,
+			Text:      fmt.Sprint("**Email address**: ", data.Contact.Email),
+			IsVisible: true,
+		})
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeMedium,
+			Text:      fmt.Sprint("**Company**: ", data.Contact.Company),
+			IsVisible: true,
+		})
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeMedium,
+			Text:      fmt.Sprint("**Phone**: ", data.Contact.Phone),
+			IsVisible: true,
+		})
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeMedium,
+			Text:      fmt.Sprint("**Details**: ", data.Contact.Details),
+			IsVisible: true,
+		})
+	}
 
-			if data.Contact != nil {
-				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-					Type:      adaptivecards.ElementTypeTextBlock,
-					Size:      adaptivecards.FontSizeMedium,
-					Text:      fmt.Sprint("**First name**: ", data.Contact.Firstname),
-					IsVisible: true,
-					Separator: true,
-				})
-				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-					Type:      adaptivecards.ElementTypeTextBlock,
-					Size:      adaptivecards.FontSizeMedium,
-					Text:      fmt.Sprint("**Last name**: ", data.Contact.Lastname),
-					IsVisible: true,
-				})
-				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-					Type:      adaptivecards.ElementTypeTextBlock,
-					Size:      adaptivecards.FontSizeMedium,
-					Text:      fmt.Sprint("**Email address**: ", data.Contact.Email),
-					IsVisible: true,
-				})
-				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-					Type:      adaptivecards.ElementTypeTextBlock,
-					Size:      adaptivecards.FontSizeMedium,
-					Text:      fmt.Sprint("**Company**: ", data.Contact.Company),
-					IsVisible: true,
-				})
-				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-					Type:      adaptivecards.ElementTypeTextBlock,
-					Size:      adaptivecards.FontSizeMedium,
-					Text:      fmt.Sprint("**Phone**: ", data.Contact.Phone),
-					IsVisible: true,
-				})
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+			Type:      adaptivecards.ElementTypeTextBlock,
+			Size:      adaptivecards.FontSizeLarge,
+			Text:      node.NodeTranslation,
+			IsVisible: true,
+			Separator: true,
+		})
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
 				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
 					Type:      adaptivecards.ElementTypeTextBlock,
 					Size:      adaptivecards.FontSizeMedium,
-					Text:      fmt.Sprint("**Details**: ", data.Contact.Details),
-					IsVisible: true,
-				})
-			}
-
-			for _, node := range data.Nodes {
-				if node.NodeTranslation == "" {
-					node.NodeTranslation = "Missing Translation"
-				}
-				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-					Type:      adaptivecards.ElementTypeTextBlock,
-					Size:      adaptivecards.FontSizeLarge,
-					Text:      node.NodeTranslation,
+					Text:      fmt.Sprint("- ", element.Label),
 					IsVisible: true,
-					Separator: true,
 				})
-				switch node.NodeType {
-				case 0:
-					for _, element := range node.ChoiceNode.Elements {
-						card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-							Type:      adaptivecards.ElementTypeTextBlock,
-							Size:      adaptivecards.FontSizeMedium,
-							Text:      fmt.Sprint("- ", element.Label),
-							IsVisible: true,
-						})
-						if element.AnswerShort != "" {
-							card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-								Type:      adaptivecards.ElementTypeTextBlock,
-								Size:      adaptivecards.FontSizeMedium,
-								Text:      element.AnswerShort,
-								IsVisible: true,
-								Wrap:      true,
-								IsSubtle:  true,
-							})
-						}
-						if element.AnswerLong != "" {
-							card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-								Type:      adaptivecards.ElementTypeTextBlock,
-								Size:      adaptivecards.FontSizeMedium,
-								Text:      element.AnswerLong,
-								IsVisible: true,
-								Wrap:      true,
-								IsSubtle:  true,
-							})
-						}
-					}
-				case 1:
-					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-						Type:      adaptivecards.ElementTypeTextBlock,
-						Size:      adaptivecards.FontSizeMedium,
-						Text:      node.SelectNode.Label,
-						IsVisible: true,
-					})
-
-					for _, selected := range node.SelectNode.Selected {
-						card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-							Type:      adaptivecards.ElementTypeTextBlock,
-							Text:      fmt.Sprint("- ", selected),
-							IsVisible: true,
-						})
-					}
-
-				case 2:
-					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-						Type:      adaptivecards.ElementTypeTextBlock,
-						Size:      adaptivecards.FontSizeMedium,
-						Text:      fmt.Sprint("**First name**: ", node.ContactNode.Firstname),
-						IsVisible: true,
-					})
-					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-						Type:      adaptivecards.ElementTypeTextBlock,
-						Size:      adaptivecards.FontSizeMedium,
-						Text:      fmt.Sprint("**Last name**: ", node.ContactNode.Lastname),
-						IsVisible: true,
-					})
+				if element.AnswerShort != "" {
 					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
 						Type:      adaptivecards.ElementTypeTextBlock,
 						Size:      adaptivecards.FontSizeMedium,
-						Text:      fmt.Sprint("**Email address**: ", node.ContactNode.Email),
-						IsVisible: true,
-					})
-					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-						Type:      adaptivecards.ElementTypeTextBlock,
-						Size:      adaptivecards.FontSizeMedium,
-						Text:      fmt.Sprint("**Company**: ", node.ContactNode.Company),
-						IsVisible: true,
-					})
-					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-						Type:      adaptivecards.ElementTypeTextBlock,
-						Size:      adaptivecards.FontSizeMedium,
-						Text:      fmt.Sprint("**Phone**: ", node.ContactNode.Phone),
+						Text:      element.AnswerShort,
 						IsVisible: true,
+						Wrap:      true,
+						IsSubtle:  true,
 					})
+				}
+				if element.AnswerLong != "" {
 					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
 						Type:      adaptivecards.ElementTypeTextBlock,
 						Size:      adaptivecards.FontSizeMedium,
-						Text:      fmt.Sprint("**Details**: ", node.ContactNode.Details),
+						Text:      element.AnswerLong,
 						IsVisible: true,
+						Wrap:      true,
+						IsSubtle:  true,
 					})
+				}
+			}
+		case 1:
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      node.SelectNode.Label,
+				IsVisible: true,
+			})
 
-				case 3:
-					card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-						Type:      adaptivecards.ElementTypeTextBlock,
-						Size:      adaptivecards.FontSizeMedium,
-						Text:      node.RatingNode.Label,
-						IsVisible: true,
-					})
+			for _, selected := range node.SelectNode.Selected {
+				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+					Type:      adaptivecards.ElementTypeTextBlock,
+					Text:      fmt.Sprint("- ", selected),
+					IsVisible: true,
+				})
+			}
 
-					for _, element := range node.RatingNode.Elements {
-						card.Body = append(card.Body, adaptivecards.ElementTextBlock{
-							Type:      adaptivecards.ElementTypeTextBlock,
-							Text:      fmt.Sprintf("- %s **%d/10** ⭐", element.Label, element.Value),
-							IsVisible: true,
-						})
-					}
+		case 2:
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      fmt.Sprint("**First name**: ", node.ContactNode.Firstname),
+				IsVisible: true,
+			})
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      fmt.Sprint("**Last name**: ", node.ContactNode.Lastname),
+				IsVisible: true,
+			})
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      fmt.Sprint("**Email address**: ", node.ContactNode.Email),
+				IsVisible: true,
+			})
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      fmt.Sprint("**Company**: ", node.ContactNode.Company),
+				IsVisible: true,
+			})
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      fmt.Sprint("**Phone**: ", node.ContactNode.Phone),
+				IsVisible: true,
+			})
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      fmt.Sprint("**Details**: ", node.ContactNode.Details),
+				IsVisible: true,
+			})
 
-				default:
-					slog.Warn("unknown node type", "nodeType", node.NodeType, "adapter", "teams")
-					continue
-				}
+		case 3:
+			card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+				Type:      adaptivecards.ElementTypeTextBlock,
+				Size:      adaptivecards.FontSizeMedium,
+				Text:      node.RatingNode.Label,
+				IsVisible: true,
+			})
+
+			for _, element := range node.RatingNode.Elements {
+				card.Body = append(card.Body, adaptivecards.ElementTextBlock{
+					Type:      adaptivecards.ElementTypeTextBlock,
+					Text:      fmt.Sprintf("- %s **%d/10** ⭐", element.Label, element.Value),
+					IsVisible: true,
+				})
 			}
 
-			return &Webhook{
-				Data: teamsData{
-					Type: "message",
-					Attachments: []teamsDataAttachment{
-						{
-							ContentType: "application/vnd.microsoft.card.adaptive",
-							Content:     *card,
-						},
-					},
-				},
-				Headers: nil,
-			}, nil
-		} else {
-			return nil, errors.New("type assertion failed for InputFormFinished")
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", adapter)
+			continue
 		}
-	default:
-		slog.Warn("unknown event type", "eventType", eventType, "adapter", "slack")
-		return nil, errors.New("unknown event type")
 	}
+
+	return card
+}
+
+func teams(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	card := teamsCard(ctx, data, "teams")
+	card.Schema = "" // $ sign in $schema struct tag trips convoy up
+
+	return &Webhook{
+		Data: teamsData{
+			Type: "message",
+			Attachments: []teamsDataAttachment{
+				{
+					ContentType: "application/vnd.microsoft.card.adaptive",
+					Content:     *card,
+				},
+			},
+		},
+		Headers: nil,
+	}, nil
+}
+
+// teamsWorkflowsCardVersion is the newest adaptive card version the Teams
+// Workflows "Post to a channel when a webhook request is received" flow renders.
+const teamsWorkflowsCardVersion = "1.4"
+
+type teamsWorkflowsDataAttachment struct {
+	ContentType string                     `json:"contentType"`
+	ContentUrl  *string                    `json:"contentUrl"`
+	Content     adaptivecards.AdaptiveCard `json:"content"`
+}
+
+type teamsWorkflowsData struct {
+	Type        string                         `json:"type"`
+	Attachments []teamsWorkflowsDataAttachment `json:"attachments"`
+}
+
+// teamsWorkflows targets Power Automate Workflows, which replace the retired
+// Office 365 connectors. Unlike connectors the flow validates the card, so
+// the version is always sent and contentUrl is an explicit null.
+func teamsWorkflows(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	card := teamsCard(ctx, data, "teamsworkflows")
+	card.Schema = "" // $ sign in $schema struct tag trips convoy up
+	card.Version = teamsWorkflowsCardVersion
+
+	return &Webhook{
+		Data: teamsWorkflowsData{
+			Type: "message",
+			Attachments: []teamsWorkflowsDataAttachment{
+				{
+					ContentType: "application/vnd.microsoft.card.adaptive",
+					ContentUrl:  nil,
+					Content:     *card,
+				},
+			},
+		},
+		Headers: nil,
+	}, nil
 }
@@ -0,0 +1,107 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// TestGenericGolden pins schema 1.0 of the generic adapter. A change to
+// testdata/generic.golden.json means the schema changed and
+// GenericSchemaVersion has to be bumped.
+func TestGenericGolden(t *testing.T) {
+	data := &InputFormFinished{
+		Title:           "New submission",
+		LinkText:        "Open",
+		LinkUrl:         "https://example.com/submission",
+		FormTranslation: "Feedback",
+		Contact: &InputContactNode{
+			Firstname: "Jane",
+			Lastname:  "Doe",
+			Email:     "jane@example.com",
+		},
+		Nodes: []InputFormFinishedNode{
+			{Relation: 1, NodeType: 0, NodeTranslation: "What did you use?"},
+			{Relation: 2, NodeType: 1, NodeTranslation: "Which plan?"},
+			{Relation: 3, NodeType: 2, NodeTranslation: "Contact", ContactNode: InputContactNode{Phone: "+1 555 0100"}},
+			{Relation: 4, NodeType: 3, NodeTranslation: "How was it?", RatingNode: InputRatingNode{Label: "Overall"}},
+			{Relation: 5, NodeType: 99, NodeTranslation: "Unknown"},
+		},
+	}
+	data.Nodes[0].ChoiceNode.Elements = append(data.Nodes[0].ChoiceNode.Elements, struct {
+		Label       string `json:"label"`
+		AnswerShort string `json:"answerShort"`
+		AnswerLong  string `json:"answerLong"`
+	}{Label: "Other", AnswerShort: "A spreadsheet"})
+	data.Nodes[1].SelectNode = InputSelectNode{Label: "Plan", Selected: []string{"Pro"}}
+	data.Nodes[3].RatingNode.Elements = append(data.Nodes[3].RatingNode.Elements, struct {
+		Label string `json:"label"`
+		Value int64  `json:"value"`
+	}{Label: "Speed", Value: 9})
+
+	// empty inputs must serialize as empty lists, not null
+	data.Nodes = append(data.Nodes,
+		InputFormFinishedNode{Relation: 6, NodeType: 0, NodeTranslation: "Empty choice"},
+		InputFormFinishedNode{Relation: 7, NodeType: 1, NodeTranslation: "Empty select"},
+		InputFormFinishedNode{Relation: 8, NodeType: 3, NodeTranslation: "Empty rating"},
+	)
+
+	ctx := WithLogger(context.Background(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	webhook, err := generic(ctx, data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.MarshalIndent(webhook.Data, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+
+	golden := "testdata/generic.golden.json"
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("generic payload differs from %s, bump GenericSchemaVersion if the change is intended:\n%s", golden, got)
+	}
+	if bytes.Contains(got, []byte("null")) {
+		t.Errorf("generic payload contains null:\n%s", got)
+	}
+}
+
+func TestAdaptiveCardsOmitSchema(t *testing.T) {
+	data := &InputFormFinished{Title: "New submission", LinkText: "Open", LinkUrl: "https://example.com"}
+	for name, adapter := range map[string]func() (*Webhook, error){
+		"teams":          func() (*Webhook, error) { return teams(context.Background(), data, nil) },
+		"teamsworkflows": func() (*Webhook, error) { return teamsWorkflows(context.Background(), data, nil) },
+		"webex":          func() (*Webhook, error) { return webex(context.Background(), data, &WebexConfig{AdaptiveCard: true}) },
+	} {
+		webhook, err := adapter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, err := json.Marshal(webhook.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(body, []byte("$schema")) {
+			t.Errorf("%s card contains $schema: %s", name, body)
+		}
+		if name == "teamsworkflows" && !bytes.Contains(body, []byte(`"version":"`+teamsWorkflowsCardVersion+`"`)) {
+			t.Errorf("%s card does not pin version %s: %s", name, teamsWorkflowsCardVersion, body)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+)
+
+type airtableData struct {
+	Records  []airtableRecord `json:"records"`
+	Typecast bool             `json:"typecast"`
+}
+
+type airtableRecord struct {
+	Fields map[string]interface{} `json:"fields"`
+}
+
+func airtable(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*DatabaseConfig)
+	if !ok || cfg == nil || cfg.Token == "" {
+		return nil, errors.New("airtable token not configured")
+	}
+	fields := map[string]interface{}{}
+	if cfg.TitleProperty != "" {
+		fields[cfg.TitleProperty] = data.Title
+	}
+	for column, value := range databaseRow(cfg, data) {
+		switch value.Type {
+		case databaseValueNumber:
+			fields[column] = value.Number
+		case databaseValueMultiSelect:
+			fields[column] = value.MultiSelect
+		default:
+			fields[column] = value.Text
+		}
+	}
+
+	return &Webhook{
+		Data: airtableData{
+			Records: []airtableRecord{
+				{
+					Fields: fields,
+				},
+			},
+			// lets airtable create select options it does not know yet
+			Typecast: true,
+		},
+		Headers: map[string][]string{
+			"Authorization": {"Bearer " + cfg.Token},
+		},
+	}, nil
+}
@@ -0,0 +1,157 @@
+package integrations
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const cloudEventsSpecVersion = "1.0"
+
+// CloudEventsConfig configures the CloudEvents envelope. Source identifies
+// the producer as URI reference and is required. Binary switches from
+// structured mode, where the envelope is the body, to binary mode, where the
+// attributes travel as ce-* headers next to the unchanged payload. Subject
+// defaults to the form name for the dedicated integration type.
+type CloudEventsConfig struct {
+	Source     string
+	Subject    string
+	DataSchema string
+	Binary     bool
+}
+
+type cloudEventsData struct {
+	SpecVersion     string      `json:"specversion"`
+	ID              string      `json:"id"`
+	Source          string      `json:"source"`
+	Type            string      `json:"type"`
+	Time            string      `json:"time"`
+	Subject         string      `json:"subject,omitempty"`
+	DataSchema      string      `json:"dataschema,omitempty"`
+	DataContentType string      `json:"datacontenttype"`
+	Data            interface{} `json:"data"`
+}
+
+func cloudEventsID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	// uuid version 4, variant 1
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]), nil
+}
+
+// WrapCloudEvent wraps the payload of webhook, as produced by any adapter,
+// into a CloudEvents 1.0 envelope of eventType. Followups and the requests
+// built by Then are wrapped as separate events.
+func WrapCloudEvent(webhook *Webhook, eventType EventType, cfg *CloudEventsConfig) (*Webhook, error) {
+	if webhook == nil {
+		return nil, errors.New("webhook undefined")
+	}
+	if cfg == nil || cfg.Source == "" {
+		return nil, errors.New("cloudevents source not configured")
+	}
+	id, err := cloudEventsID()
+	if err != nil {
+		return nil, err
+	}
+
+	data := webhook.Data
+	contentType := "application/json"
+	switch payload := webhook.Data.(type) {
+	case string:
+		contentType = "text/plain; charset=utf-8"
+	case url.Values:
+		contentType = "application/x-www-form-urlencoded"
+		data = payload.Encode()
+	}
+
+	headers := map[string][]string{}
+	for key, values := range webhook.Headers {
+		headers[key] = values
+	}
+
+	wrapped := &Webhook{
+		Method:  webhook.Method,
+		Path:    webhook.Path,
+		Headers: headers,
+		Query:   webhook.Query,
+	}
+	event := cloudEventsData{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              id,
+		Source:          cfg.Source,
+		Type:            string(eventType),
+		Time:            time.Now().UTC().Format(time.RFC3339Nano),
+		Subject:         cfg.Subject,
+		DataSchema:      cfg.DataSchema,
+		DataContentType: contentType,
+		Data:            data,
+	}
+
+	if cfg.Binary {
+		wrapped.Data = webhook.Data
+		headers["Content-Type"] = []string{contentType}
+		headers["ce-specversion"] = []string{event.SpecVersion}
+		headers["ce-id"] = []string{event.ID}
+		headers["ce-source"] = []string{event.Source}
+		headers["ce-type"] = []string{event.Type}
+		headers["ce-time"] = []string{event.Time}
+		if event.Subject != "" {
+			headers["ce-subject"] = []string{event.Subject}
+		}
+		if event.DataSchema != "" {
+			headers["ce-dataschema"] = []string{event.DataSchema}
+		}
+	} else {
+		wrapped.Data = event
+		headers["Content-Type"] = []string{"application/cloudevents+json; charset=utf-8"}
+	}
+
+	if webhook.Then != nil {
+		wrapped.Then = func(result *DeliveryResult) (*Webhook, error) {
+			next, err := webhook.Then(result)
+			if err != nil || next == nil {
+				return next, err
+			}
+			return WrapCloudEvent(next, eventType, cfg)
+		}
+	}
+
+	for _, followup := range webhook.Followups {
+		wrappedFollowup, err := WrapCloudEvent(followup, eventType, cfg)
+		if err != nil {
+			return nil, err
+		}
+		wrapped.Followups = append(wrapped.Followups, wrappedFollowup)
+	}
+
+	return wrapped, nil
+}
+
+// cloudEvents wraps the canonical payload of the generic adapter.
+func cloudEvents(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*CloudEventsConfig)
+	if !ok || cfg == nil {
+		return nil, errors.New("cloudevents source not configured")
+	}
+	webhook, err := generic(ctx, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	eventCfg := *cfg
+	if eventCfg.Subject == "" {
+		eventCfg.Subject = data.FormTranslation
+	}
+	if eventCfg.DataSchema == "" {
+		eventCfg.DataSchema = "urn:formflake:generic:" + GenericSchemaVersion
+	}
+
+	return WrapCloudEvent(webhook, data.EventType(), &eventCfg)
+}
@@ -0,0 +1,310 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CRMConfig configures the HubSpot and Pipedrive adapters. Properties maps
+// the json name of an InputContactNode field (firstname, lastname, email,
+// company, phone, details) to the property it is stored in, an empty name
+// leaves the field to the note.
+type CRMConfig struct {
+	Token      string
+	Properties map[string]string
+}
+
+var hubSpotProperties = map[string]string{
+	"firstname": "firstname",
+	"lastname":  "lastname",
+	"email":     "email",
+	"company":   "company",
+	"phone":     "phone",
+}
+
+var pipedriveProperties = map[string]string{
+	"firstname": "first_name",
+	"lastname":  "last_name",
+}
+
+// crmProperty looks up the property name of a contact field, mappings of
+// cfg take precedence over the defaults of the platform.
+func crmProperty(cfg *CRMConfig, defaults map[string]string, field string) string {
+	if property, ok := cfg.Properties[field]; ok {
+		return property
+	}
+	return defaults[field]
+}
+
+// crmLead splits data into the contact of the respondent and a copy of data
+// holding the remaining answers, as well as the contact fields that are not
+// mapped to a property and belong into the note.
+func crmLead(cfg *CRMConfig, defaults map[string]string, data *InputFormFinished) (map[string]string, *InputFormFinished, error) {
+	contact := submissionContact(data)
+	if contact == nil {
+		return nil, nil, errors.New("no contact in input")
+	}
+
+	remaining := *data
+	remaining.Contact = nil
+	remaining.Nodes = []InputFormFinishedNode{}
+	for idx := range data.Nodes {
+		if &data.Nodes[idx].ContactNode != contact {
+			remaining.Nodes = append(remaining.Nodes, data.Nodes[idx])
+		}
+	}
+
+	properties := map[string]string{}
+	unmapped := &InputContactNode{}
+	for _, field := range filledContactFields(contact) {
+		if property := crmProperty(cfg, defaults, field.Name); property != "" {
+			properties[property] = field.Value
+			continue
+		}
+		switch field.Name {
+		case "firstname":
+			unmapped.Firstname = field.Value
+		case "lastname":
+			unmapped.Lastname = field.Value
+		case "email":
+			unmapped.Email = field.Value
+		case "company":
+			unmapped.Company = field.Value
+		case "phone":
+			unmapped.Phone = field.Value
+		case "details":
+			unmapped.Details = field.Value
+		}
+	}
+	if len(filledContactFields(unmapped)) > 0 {
+		remaining.Contact = unmapped
+	}
+
+	return properties, &remaining, nil
+}
+
+// hubSpotNoteToContact is the HubSpot defined association type of a note
+// to a contact.
+const hubSpotNoteToContact = 202
+
+// HubSpotObject is the body of a request creating a CRM object.
+type HubSpotObject struct {
+	Properties   map[string]string    `json:"properties"`
+	Associations []HubSpotAssociation `json:"associations,omitempty"`
+}
+
+type HubSpotAssociation struct {
+	To    HubSpotAssociationTarget `json:"to"`
+	Types []HubSpotAssociationType `json:"types"`
+}
+
+type HubSpotAssociationTarget struct {
+	ID string `json:"id"`
+}
+
+type HubSpotAssociationType struct {
+	AssociationCategory string `json:"associationCategory"`
+	AssociationTypeID   int    `json:"associationTypeId"`
+}
+
+// crmCreatedID returns the id of the object created by a request, found at
+// path in the json response, e.g. ["data", "id"] for Pipedrive.
+func crmCreatedID(result *DeliveryResult, path ...string) (string, error) {
+	var value interface{}
+	if err := json.Unmarshal(result.Body, &value); err != nil {
+		return "", fmt.Errorf("invalid crm response: %w", err)
+	}
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", errors.New("crm response without id")
+		}
+		value = object[key]
+	}
+	switch id := value.(type) {
+	case string:
+		if id != "" {
+			return id, nil
+		}
+	case float64:
+		return strconv.FormatInt(int64(id), 10), nil
+	}
+	return "", errors.New("crm response without id")
+}
+
+// hubSpot creates a contact and then a note with the remaining answers
+// associated to it. The webhook url is https://api.hubapi.com/crm/v3/objects,
+// the object type is appended as Path.
+func hubSpot(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*CRMConfig)
+	if !ok || cfg == nil || cfg.Token == "" {
+		return nil, errors.New("hubspot token not configured")
+	}
+	properties, remaining, err := crmLead(cfg, hubSpotProperties, data)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string][]string{
+		"Authorization": {"Bearer " + cfg.Token},
+	}
+	note := HubSpotObject{
+		Properties: map[string]string{
+			"hs_note_body": newHTMLMessage(ctx, remaining, "hubspot").formatted.String(),
+			"hs_timestamp": time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	return &Webhook{
+		Path: "contacts",
+		Data: HubSpotObject{
+			Properties: properties,
+		},
+		Headers: headers,
+		Then: func(result *DeliveryResult) (*Webhook, error) {
+			contactID, err := crmCreatedID(result, "id")
+			if err != nil {
+				return nil, err
+			}
+			note.Associations = []HubSpotAssociation{{
+				To: HubSpotAssociationTarget{ID: contactID},
+				Types: []HubSpotAssociationType{{
+					AssociationCategory: "HUBSPOT_DEFINED",
+					AssociationTypeID:   hubSpotNoteToContact,
+				}},
+			}}
+			return &Webhook{
+				Path:    "notes",
+				Data:    note,
+				Headers: headers,
+			}, nil
+		},
+	}, nil
+}
+
+type PipedrivePerson struct {
+	Name   string                 `json:"name"`
+	Email  []PipedriveContactInfo `json:"email,omitempty"`
+	Phone  []PipedriveContactInfo `json:"phone,omitempty"`
+	Fields map[string]string      `json:"-"`
+}
+
+type PipedriveContactInfo struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary"`
+}
+
+type PipedriveLeadData struct {
+	Title    string `json:"title"`
+	PersonID int64  `json:"person_id"`
+}
+
+type PipedriveNote struct {
+	Content string `json:"content"`
+	LeadID  string `json:"lead_id"`
+}
+
+// MarshalJSON inlines the mapped custom fields next to the standard ones.
+func (person PipedrivePerson) MarshalJSON() ([]byte, error) {
+	body := map[string]interface{}{
+		"name": person.Name,
+	}
+	for key, value := range person.Fields {
+		body[key] = value
+	}
+	if len(person.Email) > 0 {
+		body["email"] = person.Email
+	}
+	if len(person.Phone) > 0 {
+		body["phone"] = person.Phone
+	}
+	return json.Marshal(body)
+}
+
+// pipedrive creates a person, then a lead for the person and a note with
+// the remaining answers on the lead. The webhook url is
+// https://api.pipedrive.com/v1, the endpoint is appended as Path.
+func pipedrive(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*CRMConfig)
+	if !ok || cfg == nil || cfg.Token == "" {
+		return nil, errors.New("pipedrive token not configured")
+	}
+	contact := submissionContact(data)
+	properties, remaining, err := crmLead(cfg, pipedriveProperties, data)
+	if err != nil {
+		return nil, err
+	}
+
+	person := PipedrivePerson{
+		Name:   strings.TrimSpace(contact.Firstname + " " + contact.Lastname),
+		Fields: properties,
+	}
+	if person.Name == "" {
+		person.Name = contact.Email
+	}
+	if contact.Email != "" {
+		person.Email = []PipedriveContactInfo{{Value: contact.Email, Primary: true}}
+	}
+	if contact.Phone != "" {
+		person.Phone = []PipedriveContactInfo{{Value: contact.Phone, Primary: true}}
+	}
+	// email and phone are part of the person itself
+	if remaining.Contact != nil {
+		remaining.Contact.Email = ""
+		remaining.Contact.Phone = ""
+		if len(filledContactFields(remaining.Contact)) == 0 {
+			remaining.Contact = nil
+		}
+	}
+
+	title := data.Title
+	if person.Name != "" {
+		title += " - " + person.Name
+	}
+	headers := map[string][]string{
+		"x-api-token": {cfg.Token},
+	}
+	note := newHTMLMessage(ctx, remaining, "pipedrive").formatted.String()
+
+	return &Webhook{
+		Path:    "persons",
+		Data:    person,
+		Headers: headers,
+		Then: func(result *DeliveryResult) (*Webhook, error) {
+			personID, err := crmCreatedID(result, "data", "id")
+			if err != nil {
+				return nil, err
+			}
+			id, err := strconv.ParseInt(personID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pipedrive person id: %w", err)
+			}
+			return &Webhook{
+				Path: "leads",
+				Data: PipedriveLeadData{
+					Title:    title,
+					PersonID: id,
+				},
+				Headers: headers,
+				Then: func(result *DeliveryResult) (*Webhook, error) {
+					leadID, err := crmCreatedID(result, "data", "id")
+					if err != nil {
+						return nil, err
+					}
+					return &Webhook{
+						Path: "notes",
+						Data: PipedriveNote{
+							Content: note,
+							LeadID:  leadID,
+						},
+						Headers: headers,
+					}, nil
+				},
+			}, nil
+		},
+	}, nil
+}
@@ -0,0 +1,128 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func crmTestData() *InputFormFinished {
+	return &InputFormFinished{
+		Title:    "New lead",
+		LinkText: "Open",
+		LinkUrl:  "https://example.com/submission",
+		Contact: &InputContactNode{
+			Firstname: "Jane",
+			Lastname:  "Doe",
+			Email:     "jane@example.com",
+		},
+		Nodes: []InputFormFinishedNode{
+			{NodeType: 1, NodeTranslation: "Budget", SelectNode: InputSelectNode{Selected: []string{"10k"}}},
+		},
+	}
+}
+
+func TestDeliverHubSpot(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, request deliveryRequest) {
+		w.WriteHeader(http.StatusCreated)
+		if strings.HasSuffix(request.path, "/contacts") {
+			w.Write([]byte(`{"id":"512","properties":{}}`))
+			return
+		}
+		w.Write([]byte(`{"id":"77"}`))
+	})
+
+	webhook, err := hubSpot(context.Background(), crmTestData(), &CRMConfig{Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&Deliverer{}).Deliver(context.Background(), server.URL+"/crm/v3/objects", webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	delivered := requests()
+	if len(delivered) != 2 || delivered[0].path != "/crm/v3/objects/contacts" || delivered[1].path != "/crm/v3/objects/notes" {
+		t.Fatalf("requests = %+v, want a contact and a note", delivered)
+	}
+	contact := HubSpotObject{}
+	if err := json.Unmarshal([]byte(delivered[0].body), &contact); err != nil {
+		t.Fatal(err)
+	}
+	if contact.Properties["email"] != "jane@example.com" {
+		t.Errorf("contact = %s", delivered[0].body)
+	}
+	note := HubSpotObject{}
+	if err := json.Unmarshal([]byte(delivered[1].body), &note); err != nil {
+		t.Fatal(err)
+	}
+	if len(note.Associations) != 1 || note.Associations[0].To.ID != "512" {
+		t.Errorf("note is not associated with the contact: %s", delivered[1].body)
+	}
+	if !strings.Contains(note.Properties["hs_note_body"], "10k") {
+		t.Errorf("note misses the answers: %s", delivered[1].body)
+	}
+}
+
+func TestDeliverPipedrive(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, request deliveryRequest) {
+		w.WriteHeader(http.StatusCreated)
+		switch {
+		case strings.HasSuffix(request.path, "/persons"):
+			w.Write([]byte(`{"success":true,"data":{"id":42}}`))
+		case strings.HasSuffix(request.path, "/leads"):
+			w.Write([]byte(`{"success":true,"data":{"id":"adf21080-0e10-11eb-879b-05d71fb426ec"}}`))
+		default:
+			w.Write([]byte(`{"success":true,"data":{"id":7}}`))
+		}
+	})
+
+	webhook, err := pipedrive(context.Background(), crmTestData(), &CRMConfig{Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&Deliverer{}).Deliver(context.Background(), server.URL+"/v1", webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	delivered := requests()
+	paths := []string{}
+	for _, request := range delivered {
+		paths = append(paths, request.path)
+	}
+	if strings.Join(paths, ",") != "/v1/persons,/v1/leads,/v1/notes" {
+		t.Fatalf("paths = %q", paths)
+	}
+	lead := PipedriveLeadData{}
+	if err := json.Unmarshal([]byte(delivered[1].body), &lead); err != nil {
+		t.Fatal(err)
+	}
+	if lead.PersonID != 42 || lead.Title != "New lead - Jane Doe" {
+		t.Errorf("lead = %s", delivered[1].body)
+	}
+	note := PipedriveNote{}
+	if err := json.Unmarshal([]byte(delivered[2].body), &note); err != nil {
+		t.Fatal(err)
+	}
+	if note.LeadID != "adf21080-0e10-11eb-879b-05d71fb426ec" || !strings.Contains(note.Content, "10k") {
+		t.Errorf("note = %s", delivered[2].body)
+	}
+}
+
+func TestDeliverCRMWithoutID(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, _ deliveryRequest) {
+		w.Write([]byte(`{"success":true,"data":null}`))
+	})
+
+	webhook, err := pipedrive(context.Background(), crmTestData(), &CRMConfig{Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&Deliverer{}).Deliver(context.Background(), server.URL, webhook); err == nil {
+		t.Error("delivered the lead without a person id")
+	}
+	if len(requests()) != 1 {
+		t.Errorf("got %d requests, want only the person", len(requests()))
+	}
+}
@@ -0,0 +1,97 @@
+package integrations
+
+import (
+	"strings"
+)
+
+// DatabaseConfig configures the Notion and Airtable adapters. Columns maps
+// the Relation of a node to the property or column its answer is stored in
+// and ContactColumns the json name of a contact field (firstname, lastname,
+// email, company, phone, details) to one. Ratings are stored as the average
+// of their elements, selections as multi select and the contact email as
+// email; everything else is text.
+type DatabaseConfig struct {
+	Token          string
+	DatabaseID     string
+	TitleProperty  string
+	Columns        map[int64]string
+	ContactColumns map[string]string
+}
+
+type databaseValueType int
+
+const (
+	databaseValueText databaseValueType = iota
+	databaseValueNumber
+	databaseValueMultiSelect
+	databaseValueEmail
+)
+
+// databaseValue is a typed cell of a database row.
+type databaseValue struct {
+	Type        databaseValueType
+	Text        string
+	Number      float64
+	MultiSelect []string
+}
+
+// databaseRow collects the typed values of all mapped nodes and contact
+// fields of data, keyed by column.
+func databaseRow(cfg *DatabaseConfig, data *InputFormFinished) map[string]databaseValue {
+	row := map[string]databaseValue{}
+
+	if contact := submissionContact(data); contact != nil {
+		for _, field := range filledContactFields(contact) {
+			column, ok := cfg.ContactColumns[field.Name]
+			if !ok || column == "" {
+				continue
+			}
+			value := databaseValue{Type: databaseValueText, Text: field.Value}
+			if field.Name == "email" {
+				value.Type = databaseValueEmail
+			}
+			row[column] = value
+		}
+	}
+
+	for _, node := range data.Nodes {
+		column, ok := cfg.Columns[node.Relation]
+		if !ok || column == "" {
+			continue
+		}
+		switch node.NodeType {
+		case 0:
+			lines := []string{}
+			for _, element := range node.ChoiceNode.Elements {
+				answers := []string{element.Label}
+				for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+					if answer != "" {
+						answers = append(answers, answer)
+					}
+				}
+				lines = append(lines, strings.Join(answers, ": "))
+			}
+			row[column] = databaseValue{Type: databaseValueText, Text: strings.Join(lines, "\n")}
+		case 1:
+			row[column] = databaseValue{Type: databaseValueMultiSelect, MultiSelect: node.SelectNode.Selected}
+		case 2:
+			contact := node.ContactNode
+			details := []string{}
+			for _, field := range filledContactFields(&contact) {
+				details = append(details, field.Value)
+			}
+			row[column] = databaseValue{Type: databaseValueText, Text: strings.Join(details, "\n")}
+		case 3:
+			if len(node.RatingNode.Elements) == 0 {
+				continue
+			}
+			var sum int64
+			for _, element := range node.RatingNode.Elements {
+				sum += element.Value
+			}
+			row[column] = databaseValue{Type: databaseValueNumber, Number: float64(sum) / float64(len(node.RatingNode.Elements))}
+		}
+	}
+
+	return row
+}
@@ -0,0 +1,275 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const defaultMaxResponseBytes = 64 << 10
+
+// ErrDeliveryStatus is wrapped by the error Deliver returns when the
+// platform answers with a status other than 2xx or reports an error in the
+// body of a successful response.
+var ErrDeliveryStatus = errors.New("delivery failed")
+
+// Deliverer sends webhooks over HTTP.
+type Deliverer struct {
+	// Client defaults to an http.Client with a 30 second timeout.
+	Client *http.Client
+	// MaxResponseBytes limits how much of the response body is kept in
+	// DeliveryResult.Body, defaults to 64 KiB.
+	MaxResponseBytes int64
+	// Retry is applied to each message separately, nil disables retries.
+	Retry *RetryPolicy
+}
+
+// DeliveryResult describes the response of a single delivery and of its
+// followups, which are only sent once the previous message succeeded.
+type DeliveryResult struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Latency    time.Duration
+	// PlatformError is the error message the platform reported, if any.
+	PlatformError string
+	// Attempts is the number of attempts made until this response.
+	Attempts  int
+	Followups []*DeliveryResult
+}
+
+// Success reports whether the platform accepted the webhook.
+func (result *DeliveryResult) Success() bool {
+	return result.StatusCode >= 200 && result.StatusCode < 300 && result.PlatformError == ""
+}
+
+var defaultDeliverer = &Deliverer{Retry: &RetryPolicy{}}
+
+// transportError marks errors of the HTTP exchange itself, as opposed to
+// webhooks that cannot be encoded or urls that cannot be parsed, so they can
+// be told apart when deciding about a retry.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// Deliver sends webhook to url with the default Deliverer, which makes up
+// to 3 attempts per message, i.e. at most 2 retries. A rate limited message
+// waits for the Retry-After of the platform, so a 429 can block the call for
+// up to 30s per message.
+func Deliver(ctx context.Context, url string, webhook *Webhook) (*DeliveryResult, error) {
+	return defaultDeliverer.Deliver(ctx, url, webhook)
+}
+
+// encodeWebhook encodes the Data of webhook according to its type: strings
+// are sent as plain text (e.g. Ntfy), url.Values form-encoded (e.g.
+// Pushover, Zulip) and everything else as JSON.
+func encodeWebhook(webhook *Webhook) ([]byte, string, error) {
+	switch data := webhook.Data.(type) {
+	case string:
+		return []byte(data), "text/plain; charset=utf-8", nil
+	case url.Values:
+		return []byte(data.Encode()), "application/x-www-form-urlencoded", nil
+	case []byte:
+		return data, "application/octet-stream", nil
+	case EmailMessage:
+		return nil, "", errors.New("email messages are delivered with SMTPSender")
+	}
+	body, err := json.Marshal(webhook.Data)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, "application/json", nil
+}
+
+// platformError extracts the error message from a response body. Besides
+// the usual error fields it detects platforms that answer 200 and report
+// failures in the body, like DingTalk, WeCom, Lark and Telegram.
+func platformError(statusCode int, body []byte) string {
+	success := statusCode >= 200 && statusCode < 300
+
+	response := map[string]interface{}{}
+	if err := json.Unmarshal(body, &response); err != nil {
+		if success {
+			return ""
+		}
+		if message := strings.TrimSpace(string(body)); message != "" {
+			return truncateRunes(message, 512)
+		}
+		return http.StatusText(statusCode)
+	}
+
+	message := ""
+	for _, key := range []string{"errmsg", "msg", "description", "message", "error", "errors"} {
+		switch value := response[key].(type) {
+		case string:
+			message = value
+		case map[string]interface{}:
+			if text, ok := value["message"].(string); ok {
+				message = text
+			} else if encoded, err := json.Marshal(value); err == nil {
+				message = string(encoded)
+			}
+		case []interface{}:
+			if encoded, err := json.Marshal(value); err == nil {
+				message = string(encoded)
+			}
+		}
+		if message != "" {
+			break
+		}
+	}
+
+	if !success {
+		if message == "" {
+			message = http.StatusText(statusCode)
+		}
+		return message
+	}
+
+	failed := false
+	if code, ok := response["errcode"].(float64); ok && code != 0 {
+		failed = true
+	}
+	if code, ok := response["code"].(float64); ok && code != 0 {
+		failed = true
+	}
+	if ok, present := response["ok"].(bool); present && !ok {
+		failed = true
+	}
+	if !failed {
+		return ""
+	}
+	if message == "" {
+		message = string(body)
+	}
+	return message
+}
+
+// Deliver sends webhook to rawURL, with the Path and Query of the webhook
+// added to it, followed by its Followups and the request built by Then,
+// whose results are added to the Followups of the result. A response other
+// than 2xx or an error reported by the platform is returned as an error
+// wrapping ErrDeliveryStatus together with the result.
+func (d *Deliverer) Deliver(ctx context.Context, rawURL string, webhook *Webhook) (*DeliveryResult, error) {
+	if webhook == nil {
+		return nil, errors.New("webhook undefined")
+	}
+
+	result, err := d.deliverWithRetry(ctx, rawURL, webhook)
+	if err != nil {
+		return result, err
+	}
+
+	for _, followup := range webhook.Followups {
+		followupResult, err := d.Deliver(ctx, rawURL, followup)
+		if followupResult != nil {
+			result.Followups = append(result.Followups, followupResult)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if webhook.Then != nil {
+		next, err := webhook.Then(result)
+		if err != nil || next == nil {
+			return result, err
+		}
+		nextResult, err := d.Deliver(ctx, rawURL, next)
+		if nextResult != nil {
+			result.Followups = append(result.Followups, nextResult)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+func (d *Deliverer) deliver(ctx context.Context, rawURL string, webhook *Webhook) (*DeliveryResult, error) {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if webhook.Path != "" {
+		target = target.JoinPath(webhook.Path)
+	}
+	if len(webhook.Query) > 0 {
+		query := target.Query()
+		for key, values := range webhook.Query {
+			query[key] = values
+		}
+		target.RawQuery = query.Encode()
+	}
+
+	body, contentType, err := encodeWebhook(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	method := webhook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", contentType)
+	for key, values := range webhook.Headers {
+		request.Header.Del(key)
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	client := d.Client
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+	maxResponseBytes := d.MaxResponseBytes
+	if maxResponseBytes == 0 {
+		maxResponseBytes = defaultMaxResponseBytes
+	}
+
+	start := time.Now()
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, &transportError{err: err}
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(io.LimitReader(response.Body, maxResponseBytes))
+	result := &DeliveryResult{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       responseBody,
+		Latency:    time.Since(start),
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.PlatformError = platformError(response.StatusCode, responseBody)
+	if !result.Success() {
+		return result, fmt.Errorf("%w: status %d: %s", ErrDeliveryStatus, result.StatusCode, result.PlatformError)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,290 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// deliveryRequest is a request received by the test server.
+type deliveryRequest struct {
+	method      string
+	path        string
+	query       url.Values
+	contentType string
+	body        string
+}
+
+// serveDeliveries records every request and answers with respond.
+func serveDeliveries(t *testing.T, respond func(w http.ResponseWriter, request deliveryRequest)) (*httptest.Server, func() []deliveryRequest) {
+	t.Helper()
+	mu := sync.Mutex{}
+	requests := []deliveryRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request := deliveryRequest{
+			method:      r.Method,
+			path:        r.URL.EscapedPath(),
+			query:       r.URL.Query(),
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		}
+		mu.Lock()
+		requests = append(requests, request)
+		mu.Unlock()
+		respond(w, request)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []deliveryRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]deliveryRequest{}, requests...)
+	}
+}
+
+func TestDeliverContentType(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, _ deliveryRequest) {
+		w.Write([]byte("ok"))
+	})
+
+	tests := []struct {
+		name        string
+		webhook     *Webhook
+		method      string
+		contentType string
+		body        string
+	}{
+		{
+			name:        "plain text",
+			webhook:     &Webhook{Data: "**hello**"},
+			method:      http.MethodPost,
+			contentType: "text/plain; charset=utf-8",
+			body:        "**hello**",
+		},
+		{
+			name:        "form",
+			webhook:     &Webhook{Data: url.Values{"token": {"abc"}, "message": {"a&b"}}},
+			method:      http.MethodPost,
+			contentType: "application/x-www-form-urlencoded",
+			body:        "message=a%26b&token=abc",
+		},
+		{
+			name:        "json",
+			webhook:     &Webhook{Data: map[string]string{"text": "hello"}},
+			method:      http.MethodPost,
+			contentType: "application/json",
+			body:        `{"text":"hello"}`,
+		},
+		{
+			name: "explicit content type",
+			webhook: &Webhook{
+				Data:    map[string]string{"id": "1"},
+				Headers: map[string][]string{"Content-Type": {"application/cloudevents+json; charset=utf-8"}},
+			},
+			method:      http.MethodPost,
+			contentType: "application/cloudevents+json; charset=utf-8",
+			body:        `{"id":"1"}`,
+		},
+		{
+			name:        "method",
+			webhook:     &Webhook{Method: http.MethodPut, Data: map[string]string{"msgtype": "m.text"}},
+			method:      http.MethodPut,
+			contentType: "application/json",
+			body:        `{"msgtype":"m.text"}`,
+		},
+	}
+	for idx, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := (&Deliverer{}).Deliver(context.Background(), server.URL, test.webhook); err != nil {
+				t.Fatal(err)
+			}
+			request := requests()[idx]
+			if request.method != test.method {
+				t.Errorf("method = %q, want %q", request.method, test.method)
+			}
+			if request.contentType != test.contentType {
+				t.Errorf("content type = %q, want %q", request.contentType, test.contentType)
+			}
+			if request.body != test.body {
+				t.Errorf("body = %q, want %q", request.body, test.body)
+			}
+		})
+	}
+}
+
+func TestDeliverRejectsNonHTTPData(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, _ deliveryRequest) {})
+
+	for _, data := range []interface{}{EmailMessage{}} {
+		if _, err := (&Deliverer{}).Deliver(context.Background(), server.URL, &Webhook{Data: data}); err == nil {
+			t.Errorf("%T delivered over http", data)
+		}
+	}
+	if len(requests()) != 0 {
+		t.Errorf("got %d requests, want none", len(requests()))
+	}
+}
+
+func TestPlatformError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       string
+	}{
+		{"slack ok", http.StatusOK, "ok", ""},
+		{"empty", http.StatusNoContent, "", ""},
+		{"json ok", http.StatusOK, `{"id":"1"}`, ""},
+		{"dingtalk ok", http.StatusOK, `{"errcode":0,"errmsg":"ok"}`, ""},
+		{"dingtalk", http.StatusOK, `{"errcode":310000,"errmsg":"sign not match"}`, "sign not match"},
+		{"wecom", http.StatusOK, `{"errcode":93000,"errmsg":"invalid webhook url"}`, "invalid webhook url"},
+		{"lark ok", http.StatusOK, `{"code":0,"msg":"success"}`, ""},
+		{"lark", http.StatusOK, `{"code":19021,"msg":"sign match fail or timestamp is not within one hour from current time"}`, "sign match fail or timestamp is not within one hour from current time"},
+		{"telegram ok", http.StatusOK, `{"ok":true,"result":{}}`, ""},
+		{"telegram", http.StatusOK, `{"ok":false,"error_code":400,"description":"Bad Request: can't parse entities"}`, "Bad Request: can't parse entities"},
+		{"slack", http.StatusBadRequest, "invalid_payload", "invalid_payload"},
+		{"slack not found", http.StatusNotFound, "channel_not_found\n", "channel_not_found"},
+		{"discord", http.StatusBadRequest, `{"message":"Invalid Form Body","code":50035}`, "Invalid Form Body"},
+		{"github", http.StatusUnprocessableEntity, `{"message":"Validation Failed","errors":[{"field":"title"}]}`, "Validation Failed"},
+		{"jira", http.StatusBadRequest, `{"errorMessages":[],"errors":{"summary":"required"}}`, `{"summary":"required"}`},
+		{"nested", http.StatusUnauthorized, `{"error":{"message":"invalid token"}}`, "invalid token"},
+		{"no body", http.StatusTooManyRequests, "", "Too Many Requests"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := platformError(test.statusCode, []byte(test.body)); got != test.want {
+				t.Errorf("platformError(%d, %q) = %q, want %q", test.statusCode, test.body, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDeliverPlatformError(t *testing.T) {
+	server, _ := serveDeliveries(t, func(w http.ResponseWriter, _ deliveryRequest) {
+		w.Write([]byte(`{"errcode":310000,"errmsg":"sign not match"}`))
+	})
+
+	result, err := (&Deliverer{}).Deliver(context.Background(), server.URL, &Webhook{Data: map[string]string{}})
+	if !errors.Is(err, ErrDeliveryStatus) {
+		t.Fatalf("err = %v, want ErrDeliveryStatus", err)
+	}
+	if result == nil || result.StatusCode != http.StatusOK || result.Success() {
+		t.Fatalf("result = %+v, want a failed 200", result)
+	}
+	if result.PlatformError != "sign not match" {
+		t.Errorf("platform error = %q", result.PlatformError)
+	}
+	if string(result.Body) != `{"errcode":310000,"errmsg":"sign not match"}` {
+		t.Errorf("body = %q", result.Body)
+	}
+}
+
+func TestDeliverQuery(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, _ deliveryRequest) {})
+
+	webhook := &Webhook{
+		Data: map[string]string{},
+		Query: url.Values{
+			"timestamp": {"1700000000000"},
+			"sign":      {"a+b/c="},
+		},
+	}
+	if _, err := (&Deliverer{}).Deliver(context.Background(), server.URL+"/robot/send?access_token=abc&timestamp=1", webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	query := requests()[0].query
+	want := url.Values{
+		"access_token": {"abc"},
+		"timestamp":    {"1700000000000"},
+		"sign":         {"a+b/c="},
+	}
+	if query.Encode() != want.Encode() {
+		t.Errorf("query = %q, want %q", query.Encode(), want.Encode())
+	}
+}
+
+func TestDeliverFollowups(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, request deliveryRequest) {
+		if request.body == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("invalid_payload"))
+		}
+	})
+
+	webhook := &Webhook{
+		Data: "1",
+		Followups: []*Webhook{
+			{Data: "2", Followups: []*Webhook{{Data: "3"}}},
+			{Data: "4"},
+		},
+	}
+	result, err := (&Deliverer{}).Deliver(context.Background(), server.URL, webhook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bodies := []string{}
+	for _, request := range requests() {
+		bodies = append(bodies, request.body)
+	}
+	if strings.Join(bodies, ",") != "1,2,3,4" {
+		t.Errorf("delivered %q, want 1,2,3,4", bodies)
+	}
+	if len(result.Followups) != 2 || len(result.Followups[0].Followups) != 1 {
+		t.Errorf("followup results do not mirror the webhooks: %+v", result)
+	}
+
+	webhook = &Webhook{
+		Data:      "5",
+		Followups: []*Webhook{{Data: "fail"}, {Data: "6"}},
+	}
+	result, err = (&Deliverer{}).Deliver(context.Background(), server.URL, webhook)
+	if !errors.Is(err, ErrDeliveryStatus) {
+		t.Fatalf("err = %v, want ErrDeliveryStatus", err)
+	}
+	if len(result.Followups) != 1 || result.Followups[0].PlatformError != "invalid_payload" {
+		t.Errorf("followup results = %+v", result.Followups)
+	}
+	if last := requests()[len(requests())-1]; last.body != "fail" {
+		t.Errorf("followups continued after a failure with %q", last.body)
+	}
+}
+
+func TestDeliverMatrixTransactionID(t *testing.T) {
+	server, requests := serveDeliveries(t, func(w http.ResponseWriter, _ deliveryRequest) {
+		w.Write([]byte(`{"event_id":"$event"}`))
+	})
+	room := server.URL + "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message"
+	data := &InputFormFinished{Title: "New submission"}
+
+	for idx := 0; idx < 2; idx++ {
+		webhook, err := matrix(context.Background(), data, &MatrixConfig{AccessToken: "token"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := (&Deliverer{}).Deliver(context.Background(), room, webhook); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	delivered := requests()
+	prefix := "/_matrix/client/v3/rooms/%21room:example.org/send/m.room.message/"
+	for _, request := range delivered {
+		if request.method != http.MethodPut {
+			t.Errorf("method = %q, want PUT", request.method)
+		}
+		if !strings.HasPrefix(request.path, prefix) || len(request.path) == len(prefix) {
+			t.Errorf("path = %q, want a transaction id below %q", request.path, prefix)
+		}
+	}
+	if delivered[0].path == delivered[1].path {
+		t.Errorf("both events were sent to %q", delivered[0].path)
+	}
+}
@@ -0,0 +1,163 @@
+package integrations
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var dingTalkContactLabels = map[string]string{
+	"firstname": "名字",
+	"lastname":  "姓氏",
+	"email":     "邮箱",
+	"company":   "公司",
+	"phone":     "电话",
+	"details":   "详情",
+}
+
+// chineseMarkdown renders data as the markdown subset understood by the
+// DingTalk and WeCom robots, with Chinese contact labels.
+func chineseMarkdown(ctx context.Context, data *InputFormFinished, adapter string) string {
+	message := &strings.Builder{}
+	fmt.Fprintf(message, "### %s\n\n", data.Title)
+	if data.FormTranslation != "" {
+		fmt.Fprintf(message, "**%s**\n\n", data.FormTranslation)
+	}
+
+	contactLines := func(contact *InputContactNode) {
+		for _, field := range filledContactFields(contact) {
+			fmt.Fprintf(message, "- **%s**：%s\n", dingTalkContactLabels[field.Name], field.Value)
+		}
+		message.WriteString("\n")
+	}
+
+	if data.Contact != nil {
+		contactLines(data.Contact)
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		switch node.NodeType {
+		case 0:
+			fmt.Fprintf(message, "#### %s\n\n", node.NodeTranslation)
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				fmt.Fprintf(message, "- %s\n", element.Label)
+				if element.AnswerShort != "" {
+					fmt.Fprintf(message, "> %s\n", element.AnswerShort)
+				}
+				if element.AnswerLong != "" {
+					fmt.Fprintf(message, "> %s\n", element.AnswerLong)
+				}
+			}
+		case 1:
+			fmt.Fprintf(message, "#### %s\n\n", node.NodeTranslation)
+			if node.SelectNode.Label != "" {
+				fmt.Fprintf(message, "%s\n\n", node.SelectNode.Label)
+			}
+			for _, selected := range node.SelectNode.Selected {
+				fmt.Fprintf(message, "- %s\n", selected)
+			}
+		case 2:
+			fmt.Fprintf(message, "#### %s\n\n", node.NodeTranslation)
+			contactLines(&node.ContactNode)
+		case 3:
+			fmt.Fprintf(message, "#### %s\n\n", node.NodeTranslation)
+			if node.RatingNode.Label != "" {
+				fmt.Fprintf(message, "%s\n\n", node.RatingNode.Label)
+			}
+			for _, element := range node.RatingNode.Elements {
+				fmt.Fprintf(message, "- %s：**%d/10** ⭐\n", element.Label, element.Value)
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", adapter)
+			continue
+		}
+		message.WriteString("\n")
+	}
+
+	return strings.TrimSpace(message.String())
+}
+
+// DingTalkConfig configures the DingTalk adapter. Secret is the signing
+// secret of a robot with the "additional signature" security setting, and
+// ActionCard switches from a markdown message to an actionCard with a button.
+type DingTalkConfig struct {
+	Secret     string
+	ActionCard bool
+}
+
+type dingTalkData struct {
+	MsgType    string              `json:"msgtype"`
+	Markdown   *dingTalkMarkdown   `json:"markdown,omitempty"`
+	ActionCard *dingTalkActionCard `json:"actionCard,omitempty"`
+}
+
+type dingTalkMarkdown struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+type dingTalkActionCard struct {
+	Title          string `json:"title"`
+	Text           string `json:"text"`
+	BtnOrientation string `json:"btnOrientation"`
+	SingleTitle    string `json:"singleTitle"`
+	SingleURL      string `json:"singleURL"`
+}
+
+// dingTalkSign computes the signature of robots, an HMAC-SHA256 keyed with
+// the secret over "timestamp\nsecret", timestamp in milliseconds.
+func dingTalkSign(timestamp int64, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func dingTalk(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, _ := config.(*DingTalkConfig)
+	text := chineseMarkdown(ctx, data, "dingtalk")
+	payload := dingTalkData{}
+
+	if cfg != nil && cfg.ActionCard {
+		payload.MsgType = "actionCard"
+		payload.ActionCard = &dingTalkActionCard{
+			Title:          data.Title,
+			Text:           text,
+			BtnOrientation: "0",
+			SingleTitle:    data.LinkText,
+			SingleURL:      data.LinkUrl,
+		}
+	} else {
+		payload.MsgType = "markdown"
+		payload.Markdown = &dingTalkMarkdown{
+			Title: data.Title,
+			Text:  fmt.Sprintf("%s\n\n[%s](%s)", text, data.LinkText, data.LinkUrl),
+		}
+	}
+
+	var query url.Values
+	if cfg != nil && cfg.Secret != "" {
+		timestamp := time.Now().UnixMilli()
+		query = url.Values{
+			"timestamp": {strconv.FormatInt(timestamp, 10)},
+			"sign":      {dingTalkSign(timestamp, cfg.Secret)},
+		}
+	}
+
+	return &Webhook{
+		Data:    payload,
+		Headers: nil,
+		Query:   query,
+	}, nil
+}
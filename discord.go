@@ -0,0 +1,166 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// limits documented at https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	discordTitleLimit       = 256
+	discordDescriptionLimit = 4096
+	discordFieldNameLimit   = 256
+	discordFieldValueLimit  = 1024
+	discordFieldsPerEmbed   = 25
+	discordEmbedsPerMessage = 10
+	discordTotalLimit       = 6000
+	discordColor            = 0x1B5495
+)
+
+type discordData struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	URL         string              `json:"url,omitempty"`
+	Color       int                 `json:"color"`
+	Fields      []discordEmbedField `json:"fields,omitempty"`
+}
+
+type discordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+func truncateRunes(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:limit-1]) + "…"
+}
+
+func discordContactFields(contact *InputContactNode) []discordEmbedField {
+	fields := []discordEmbedField{}
+	for _, field := range filledContactFields(contact) {
+		fields = append(fields, discordEmbedField{
+			Name:   field.Label,
+			Value:  truncateRunes(field.Value, discordFieldValueLimit),
+			Inline: true,
+		})
+	}
+	return fields
+}
+
+func discord(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	fields := []discordEmbedField{}
+
+	if data.Contact != nil {
+		fields = append(fields, discordContactFields(data.Contact)...)
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		value := &strings.Builder{}
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				fmt.Fprintf(value, "- %s\n", element.Label)
+				if element.AnswerShort != "" {
+					fmt.Fprintf(value, "> %s\n", element.AnswerShort)
+				}
+				if element.AnswerLong != "" {
+					fmt.Fprintf(value, "> %s\n", element.AnswerLong)
+				}
+			}
+		case 1:
+			if node.SelectNode.Label != "" {
+				fmt.Fprintf(value, "**%s**\n", node.SelectNode.Label)
+			}
+			for _, selected := range node.SelectNode.Selected {
+				fmt.Fprintf(value, "- %s\n", selected)
+			}
+		case 2:
+			fields = append(fields, discordEmbedField{
+				Name:  truncateRunes(node.NodeTranslation, discordFieldNameLimit),
+				Value: "\u200b",
+			})
+			fields = append(fields, discordContactFields(&node.ContactNode)...)
+			continue
+		case 3:
+			if node.RatingNode.Label != "" {
+				fmt.Fprintf(value, "**%s**\n", node.RatingNode.Label)
+			}
+			for _, element := range node.RatingNode.Elements {
+				fmt.Fprintf(value, "- %s: **%d/10** ⭐\n", element.Label, element.Value)
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "discord")
+			continue
+		}
+		if value.Len() == 0 {
+			// discord rejects fields with an empty value
+			value.WriteString("\u200b")
+		}
+		fields = append(fields, discordEmbedField{
+			Name:  truncateRunes(node.NodeTranslation, discordFieldNameLimit),
+			Value: truncateRunes(strings.TrimSpace(value.String()), discordFieldValueLimit),
+		})
+	}
+
+	// fields that exceed the limits of a message go into followup messages
+	messages := [][]discordEmbed{}
+	embeds := []discordEmbed{}
+	embed := discordEmbed{
+		Title:       truncateRunes(data.Title, discordTitleLimit),
+		Description: truncateRunes(data.FormTranslation, discordDescriptionLimit),
+		URL:         data.LinkUrl,
+		Color:       discordColor,
+	}
+	total := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+
+	for _, field := range fields {
+		size := utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+		switch {
+		case total+size > discordTotalLimit,
+			len(embed.Fields) == discordFieldsPerEmbed && len(embeds)+1 == discordEmbedsPerMessage:
+			messages = append(messages, append(embeds, embed))
+			embeds = []discordEmbed{}
+			embed = discordEmbed{Color: discordColor}
+			total = 0
+		case len(embed.Fields) == discordFieldsPerEmbed:
+			embeds = append(embeds, embed)
+			embed = discordEmbed{Color: discordColor}
+		}
+		embed.Fields = append(embed.Fields, field)
+		total += size
+	}
+	messages = append(messages, append(embeds, embed))
+
+	webhook := &Webhook{
+		Data: discordData{
+			Embeds: messages[0],
+		},
+		Headers: nil,
+	}
+	for _, embeds := range messages[1:] {
+		webhook.Followups = append(webhook.Followups, &Webhook{
+			Data: discordData{
+				Embeds: embeds,
+			},
+		})
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,132 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// EmailConfig configures the email adapter. Subject is a text/template
+// executed against the event input, e.g. "{{.Title}}", and defaults to the
+// title of the form.
+type EmailConfig struct {
+	From    string
+	To      []string
+	Subject string
+}
+
+// EmailMessage is the Webhook.Data of the email adapter. From and To are the
+// bare envelope addresses, Raw the complete RFC 5322 message with CRLF line
+// endings, ready to be handed to SMTPSender.Send.
+type EmailMessage struct {
+	From string
+	To   []string
+	Raw  []byte
+}
+
+func emailMIME(from *mail.Address, to []*mail.Address, subject string, plain string, html string) ([]byte, error) {
+	message := &bytes.Buffer{}
+	writer := multipart.NewWriter(message)
+
+	recipients := make([]string, len(to))
+	for idx, address := range to {
+		recipients[idx] = address.String()
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("From", from.String())
+	header.Set("To", strings.Join(recipients, ", "))
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("MIME-Version", "1.0")
+	header.Set("Content-Type", "multipart/alternative; boundary="+writer.Boundary())
+	for _, key := range []string{"From", "To", "Subject", "Date", "MIME-Version", "Content-Type"} {
+		fmt.Fprintf(message, "%s: %s\r\n", key, header.Get(key))
+	}
+	message.WriteString("\r\n")
+
+	// the last part is the preferred one, so plain text goes first
+	for _, part := range [][2]string{
+		{"text/plain; charset=utf-8", plain},
+		{"text/html; charset=utf-8", html},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part[0]},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(partWriter)
+		if _, err := encoder.Write([]byte(part[1])); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return message.Bytes(), nil
+}
+
+func email(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*EmailConfig)
+	if !ok || cfg == nil || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, errors.New("email sender or recipients not configured")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email sender: %w", err)
+	}
+	to := make([]*mail.Address, len(cfg.To))
+	envelopeTo := make([]string, len(cfg.To))
+	for idx, recipient := range cfg.To {
+		if to[idx], err = mail.ParseAddress(recipient); err != nil {
+			return nil, fmt.Errorf("invalid email recipient: %w", err)
+		}
+		envelopeTo[idx] = to[idx].Address
+	}
+	subjectTemplate := cfg.Subject
+	if subjectTemplate == "" {
+		subjectTemplate = "{{.Title}}"
+	}
+	tmpl, err := template.New("subject").Parse(subjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email subject template: %w", err)
+	}
+
+	subject := &strings.Builder{}
+	if err := tmpl.Execute(subject, data); err != nil {
+		return nil, fmt.Errorf("email subject template: %w", err)
+	}
+
+	message := newHTMLMessage(ctx, data, "email")
+	html := "<!DOCTYPE html><html><body>" + message.formatted.String() + "</body></html>"
+
+	raw, err := emailMIME(from, to, strings.TrimSpace(subject.String()), message.plain.String(), html)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Webhook{
+		Data: EmailMessage{
+			From: from.Address,
+			To:   envelopeTo,
+			Raw:  raw,
+		},
+		Headers: nil,
+	}, nil
+}
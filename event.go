@@ -0,0 +1,55 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Event is implemented by the input of every event, which ties the payload
+// to its EventType.
+type Event interface {
+	EventType() EventType
+}
+
+var _ Event = &InputFormFinished{}
+
+// TypedAdapter maps a single kind of event to the webhook of a target
+// platform.
+type TypedAdapter[E Event] func(ctx context.Context, event E, config interface{}) (*Webhook, error)
+
+// NewAdapter turns a TypedAdapter into an Adapter for the registry. Inputs
+// of other types and event types that do not match the input are rejected,
+// name identifies the adapter in logs. Adapters are not called once ctx is
+// done.
+func NewAdapter[E Event](name string, adapter TypedAdapter[E]) Adapter {
+	return func(ctx context.Context, input interface{}, eventType EventType, config interface{}) (*Webhook, error) {
+		if input == nil {
+			return nil, errors.New("input undefined")
+		}
+		event, ok := input.(E)
+		if !ok {
+			var zero E
+			return nil, fmt.Errorf("type assertion failed for %T", zero)
+		}
+		if event.EventType() != eventType {
+			loggerFrom(ctx).WarnContext(ctx, "unknown event type", "eventType", eventType, "adapter", name)
+			return nil, errors.New("unknown event type")
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return adapter(ctx, event, config)
+	}
+}
+
+// MapEvent maps event with the adapter registered under adapterType. Unlike
+// IntegrationInterface.MapWebhook the event type is taken from the event
+// itself, so payloads and event types cannot be mixed up.
+func MapEvent[E Event](ctx context.Context, adapterType IntegrationType, event E, config interface{}) (*Webhook, error) {
+	adapter, _, ok := Lookup(adapterType)
+	if !ok {
+		return nil, errors.New(fmt.Sprint("map function not defined, type: ", adapterType))
+	}
+	return adapter(ctx, event, event.EventType(), config)
+}
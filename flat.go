@@ -0,0 +1,103 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// flatKey turns a label into a key segment of lowercase letters, digits and
+// underscores, falling back to fallback for labels without any of them.
+func flatKey(label string, fallback string) string {
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '_'
+	}, label)
+	key = strings.Join(strings.FieldsFunc(key, func(r rune) bool { return r == '_' }), "_")
+	if key == "" {
+		return fallback
+	}
+	return key
+}
+
+// flatPayload is a map of flat keys, which adds a numeric suffix to keys
+// that are already taken, e.g. by two elements whose labels only differ in
+// punctuation.
+type flatPayload map[string]interface{}
+
+func (payload flatPayload) set(key string, value interface{}) {
+	unique := key
+	for idx := 2; ; idx++ {
+		if _, ok := payload[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s_%d", key, idx)
+	}
+	payload[unique] = value
+}
+
+func (payload flatPayload) contact(prefix string, contact *InputContactNode) {
+	for _, field := range contactFields(contact) {
+		payload.set(prefix+field.Name, field.Value)
+	}
+}
+
+// flat maps the input into a single level object for no-code automation
+// tools like Zapier, Make or n8n. Keys are built from the node Relation and
+// element labels, e.g. contact_email, node_1_selected or
+// node_3_rating_quality, so they stay the same across submissions.
+func flat(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	payload := flatPayload{}
+	payload.set("event", string(data.EventType()))
+	payload.set("form_title", data.Title)
+	payload.set("form_name", data.FormTranslation)
+	payload.set("link_text", data.LinkText)
+	payload.set("link_url", data.LinkUrl)
+
+	if data.Contact != nil {
+		payload.contact("contact_", data.Contact)
+	}
+
+	for _, node := range data.Nodes {
+		prefix := fmt.Sprintf("node_%d_", node.Relation)
+		switch node.NodeType {
+		case 0:
+			payload.set(prefix+"label", node.NodeTranslation)
+			for idx, element := range node.ChoiceNode.Elements {
+				answers := []string{}
+				for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+					if answer != "" {
+						answers = append(answers, answer)
+					}
+				}
+				value := strings.Join(answers, "\n")
+				if value == "" {
+					value = element.Label
+				}
+				payload.set(prefix+"choice_"+flatKey(element.Label, fmt.Sprint(idx)), value)
+			}
+		case 1:
+			payload.set(prefix+"label", node.NodeTranslation)
+			payload.set(prefix+"selected", strings.Join(node.SelectNode.Selected, ", "))
+		case 2:
+			payload.set(prefix+"label", node.NodeTranslation)
+			payload.contact(prefix+"contact_", &node.ContactNode)
+		case 3:
+			payload.set(prefix+"label", node.NodeTranslation)
+			for idx, element := range node.RatingNode.Elements {
+				payload.set(prefix+"rating_"+flatKey(element.Label, fmt.Sprint(idx)), element.Value)
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "flat")
+			continue
+		}
+	}
+
+	return &Webhook{
+		Data:    map[string]interface{}(payload),
+		Headers: nil,
+	}, nil
+}
@@ -0,0 +1,203 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+)
+
+type googleChatData struct {
+	Text    string               `json:"text,omitempty"`
+	CardsV2 []googleChatCardWrap `json:"cardsV2"`
+}
+
+type googleChatCardWrap struct {
+	CardID string         `json:"cardId"`
+	Card   googleChatCard `json:"card"`
+}
+
+type googleChatCard struct {
+	Header   *googleChatCardHeader `json:"header,omitempty"`
+	Sections []googleChatSection   `json:"sections"`
+}
+
+type googleChatCardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type googleChatSection struct {
+	Header  string             `json:"header,omitempty"`
+	Widgets []googleChatWidget `json:"widgets"`
+}
+
+type googleChatWidget struct {
+	DecoratedText *googleChatDecoratedText `json:"decoratedText,omitempty"`
+	TextParagraph *googleChatTextParagraph `json:"textParagraph,omitempty"`
+	ButtonList    *googleChatButtonList    `json:"buttonList,omitempty"`
+}
+
+type googleChatDecoratedText struct {
+	TopLabel    string `json:"topLabel,omitempty"`
+	Text        string `json:"text"`
+	BottomLabel string `json:"bottomLabel,omitempty"`
+	WrapText    bool   `json:"wrapText"`
+}
+
+type googleChatTextParagraph struct {
+	Text string `json:"text"`
+}
+
+type googleChatButtonList struct {
+	Buttons []googleChatButton `json:"buttons"`
+}
+
+type googleChatButton struct {
+	Text    string                `json:"text"`
+	OnClick googleChatButtonClick `json:"onClick"`
+}
+
+type googleChatButtonClick struct {
+	OpenLink googleChatOpenLink `json:"openLink"`
+}
+
+type googleChatOpenLink struct {
+	URL string `json:"url"`
+}
+
+func googleChatContactWidgets(contact *InputContactNode) []googleChatWidget {
+	widgets := []googleChatWidget{}
+	for _, field := range filledContactFields(contact) {
+		widgets = append(widgets, googleChatWidget{
+			DecoratedText: &googleChatDecoratedText{
+				TopLabel: field.Label,
+				Text:     html.EscapeString(field.Value),
+				WrapText: true,
+			},
+		})
+	}
+	return widgets
+}
+
+func googleChat(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	card := googleChatCard{
+		Header: &googleChatCardHeader{
+			Title:    data.Title,
+			Subtitle: data.FormTranslation,
+		},
+		Sections: []googleChatSection{},
+	}
+
+	if data.Contact != nil {
+		card.Sections = append(card.Sections, googleChatSection{
+			Header:  "Contact Information",
+			Widgets: googleChatContactWidgets(data.Contact),
+		})
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		section := googleChatSection{
+			Header:  node.NodeTranslation,
+			Widgets: []googleChatWidget{},
+		}
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				answers := []string{}
+				if element.AnswerShort != "" {
+					answers = append(answers, html.EscapeString(element.AnswerShort))
+				}
+				if element.AnswerLong != "" {
+					answers = append(answers, html.EscapeString(element.AnswerLong))
+				}
+				text := html.EscapeString(element.Label)
+				if len(answers) > 0 {
+					text = fmt.Sprintf("<b>%s</b><br>%s", text, strings.Join(answers, "<br>"))
+				}
+				section.Widgets = append(section.Widgets, googleChatWidget{
+					DecoratedText: &googleChatDecoratedText{
+						Text:     text,
+						WrapText: true,
+					},
+				})
+			}
+		case 1:
+			selected := make([]string, len(node.SelectNode.Selected))
+			for idx, item := range node.SelectNode.Selected {
+				selected[idx] = html.EscapeString(item)
+			}
+			section.Widgets = append(section.Widgets, googleChatWidget{
+				DecoratedText: &googleChatDecoratedText{
+					TopLabel: node.SelectNode.Label,
+					Text:     strings.Join(selected, "<br>"),
+					WrapText: true,
+				},
+			})
+		case 2:
+			section.Widgets = googleChatContactWidgets(&node.ContactNode)
+		case 3:
+			if node.RatingNode.Label != "" {
+				section.Widgets = append(section.Widgets, googleChatWidget{
+					TextParagraph: &googleChatTextParagraph{
+						Text: html.EscapeString(node.RatingNode.Label),
+					},
+				})
+			}
+			for _, element := range node.RatingNode.Elements {
+				section.Widgets = append(section.Widgets, googleChatWidget{
+					DecoratedText: &googleChatDecoratedText{
+						TopLabel: element.Label,
+						Text:     fmt.Sprintf("<b>%d/10</b> ⭐", element.Value),
+					},
+				})
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "googlechat")
+			continue
+		}
+		// google chat rejects sections without widgets
+		if len(section.Widgets) > 0 {
+			card.Sections = append(card.Sections, section)
+		}
+	}
+
+	if data.LinkUrl != "" {
+		card.Sections = append(card.Sections, googleChatSection{
+			Widgets: []googleChatWidget{
+				{
+					ButtonList: &googleChatButtonList{
+						Buttons: []googleChatButton{
+							{
+								Text: data.LinkText,
+								OnClick: googleChatButtonClick{
+									OpenLink: googleChatOpenLink{
+										URL: data.LinkUrl,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+
+	return &Webhook{
+		Data: googleChatData{
+			CardsV2: []googleChatCardWrap{
+				{
+					CardID: string(data.EventType()),
+					Card:   card,
+				},
+			},
+		},
+		Headers: nil,
+	}, nil
+}
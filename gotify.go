@@ -0,0 +1,65 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GotifyConfig configures the Gotify adapter. Token is the application token,
+// sent as X-Gotify-Key header when set.
+type GotifyConfig struct {
+	Token    string
+	Priority int
+}
+
+type gotifyData struct {
+	Title    string                 `json:"title"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+func gotify(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, _ := config.(*GotifyConfig)
+	if cfg == nil {
+		cfg = &GotifyConfig{}
+	}
+	lines := submissionSummary(data)
+	if len(lines) > 0 {
+		lines[0] = fmt.Sprintf("**%s**", lines[0])
+	}
+	if data.LinkUrl != "" {
+		lines = append(lines, fmt.Sprintf("[%s](%s)", data.LinkText, data.LinkUrl))
+	}
+
+	extras := map[string]interface{}{
+		"client::display": map[string]string{
+			"contentType": "text/markdown",
+		},
+	}
+	if data.LinkUrl != "" {
+		extras["client::notification"] = map[string]interface{}{
+			"click": map[string]string{
+				"url": data.LinkUrl,
+			},
+		}
+	}
+
+	var headers map[string][]string
+	if cfg.Token != "" {
+		headers = map[string][]string{
+			"X-Gotify-Key": {cfg.Token},
+		}
+	}
+
+	return &Webhook{
+		Data: gotifyData{
+			Title:    data.Title,
+			Message:  strings.Join(lines, "  \n"),
+			Priority: cfg.Priority,
+			Extras:   extras,
+		},
+		Headers: headers,
+	}, nil
+}
@@ -0,0 +1,206 @@
+package integrations
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+type HelpdeskPriority int
+
+const (
+	HelpdeskPriorityLow HelpdeskPriority = iota + 1
+	HelpdeskPriorityNormal
+	HelpdeskPriorityHigh
+	HelpdeskPriorityUrgent
+)
+
+// HelpdeskConfig configures the Zendesk and Freshdesk adapters. For Zendesk
+// Email and APIToken authenticate as "email/token", Freshdesk only needs the
+// APIToken.
+type HelpdeskConfig struct {
+	Email    string
+	APIToken string
+	Priority *HelpdeskPriorityRule
+}
+
+// HelpdeskPriorityRule derives the ticket priority from the rating node with
+// the given Relation. The rating of the element with Label is used, or the
+// lowest rating of the node if Label is empty, and the first level whose
+// MaxRating is not below it sets the priority.
+type HelpdeskPriorityRule struct {
+	Relation int64
+	Label    string
+	Levels   []HelpdeskPriorityLevel
+}
+
+type HelpdeskPriorityLevel struct {
+	MaxRating int64
+	Priority  HelpdeskPriority
+}
+
+// priority returns the priority for data, or 0 if the rule does not apply.
+func (rule *HelpdeskPriorityRule) priority(data *InputFormFinished) HelpdeskPriority {
+	if rule == nil {
+		return 0
+	}
+	for _, node := range data.Nodes {
+		if node.NodeType != 3 || node.Relation != rule.Relation {
+			continue
+		}
+		found := false
+		var rating int64
+		for _, element := range node.RatingNode.Elements {
+			if rule.Label != "" && element.Label != rule.Label {
+				continue
+			}
+			if !found || element.Value < rating {
+				rating = element.Value
+			}
+			found = true
+		}
+		if !found {
+			return 0
+		}
+		for _, level := range rule.Levels {
+			if rating <= level.MaxRating {
+				return level.Priority
+			}
+		}
+		return 0
+	}
+	return 0
+}
+
+// helpdeskTags turns the selected options of data into tags, which may not
+// contain whitespace.
+func helpdeskTags(data *InputFormFinished) []string {
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, node := range data.Nodes {
+		if node.NodeType != 1 {
+			continue
+		}
+		for _, selected := range node.SelectNode.Selected {
+			tag := strings.ToLower(strings.Join(strings.Fields(selected), "_"))
+			if tag != "" && !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+type zendeskData struct {
+	Ticket zendeskTicket `json:"ticket"`
+}
+
+type zendeskTicket struct {
+	Subject   string            `json:"subject"`
+	Comment   zendeskComment    `json:"comment"`
+	Requester *zendeskRequester `json:"requester,omitempty"`
+	Tags      []string          `json:"tags,omitempty"`
+	Priority  string            `json:"priority,omitempty"`
+}
+
+type zendeskComment struct {
+	HTMLBody string `json:"html_body"`
+}
+
+type zendeskRequester struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+var zendeskPriorities = map[HelpdeskPriority]string{
+	HelpdeskPriorityLow:    "low",
+	HelpdeskPriorityNormal: "normal",
+	HelpdeskPriorityHigh:   "high",
+	HelpdeskPriorityUrgent: "urgent",
+}
+
+func zendesk(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*HelpdeskConfig)
+	if !ok || cfg == nil || cfg.Email == "" || cfg.APIToken == "" {
+		return nil, errors.New("zendesk credentials not configured")
+	}
+	ticket := zendeskTicket{
+		Subject: data.Title,
+		Comment: zendeskComment{
+			HTMLBody: newHTMLMessage(ctx, data, "zendesk").formatted.String(),
+		},
+		Tags:     helpdeskTags(data),
+		Priority: zendeskPriorities[cfg.Priority.priority(data)],
+	}
+	if contact := submissionContact(data); contact != nil && contact.Email != "" {
+		ticket.Requester = &zendeskRequester{
+			Name:  strings.TrimSpace(contact.Firstname + " " + contact.Lastname),
+			Email: contact.Email,
+		}
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(cfg.Email + "/token:" + cfg.APIToken))
+
+	return &Webhook{
+		Data: zendeskData{
+			Ticket: ticket,
+		},
+		Headers: map[string][]string{
+			"Authorization": {"Basic " + credentials},
+		},
+	}, nil
+}
+
+type freshdeskData struct {
+	Subject     string   `json:"subject"`
+	Description string   `json:"description"`
+	Name        string   `json:"name,omitempty"`
+	Email       string   `json:"email,omitempty"`
+	Phone       string   `json:"phone,omitempty"`
+	Status      int      `json:"status"`
+	Priority    int      `json:"priority"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// freshdesk statuses and priorities are numeric, open is 2 and the default
+// priority is low
+const (
+	freshdeskStatusOpen      = 2
+	freshdeskDefaultPriority = HelpdeskPriorityLow
+)
+
+func freshdesk(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*HelpdeskConfig)
+	if !ok || cfg == nil || cfg.APIToken == "" {
+		return nil, errors.New("freshdesk api key not configured")
+	}
+	contact := submissionContact(data)
+	if contact == nil || (contact.Email == "" && contact.Phone == "") {
+		return nil, errors.New("freshdesk requires the email or phone of the requester")
+	}
+
+	priority := cfg.Priority.priority(data)
+	if priority == 0 {
+		priority = freshdeskDefaultPriority
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(cfg.APIToken + ":X"))
+
+	return &Webhook{
+		Data: freshdeskData{
+			Subject:     data.Title,
+			Description: newHTMLMessage(ctx, data, "freshdesk").formatted.String(),
+			Name:        strings.TrimSpace(contact.Firstname + " " + contact.Lastname),
+			Email:       contact.Email,
+			Phone:       contact.Phone,
+			Status:      freshdeskStatusOpen,
+			Priority:    int(priority),
+			Tags:        helpdeskTags(data),
+		},
+		Headers: map[string][]string{
+			"Authorization": {"Basic " + credentials},
+		},
+	}, nil
+}
@@ -0,0 +1,121 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// htmlMessage builds the plain and the html body of a message side by side.
+// All text passed in is escaped, so only the tags written here end up in the
+// html body.
+type htmlMessage struct {
+	plain     strings.Builder
+	formatted strings.Builder
+}
+
+func (m *htmlMessage) heading(text string) {
+	fmt.Fprintf(&m.plain, "\n%s\n", text)
+	fmt.Fprintf(&m.formatted, "<h4>%s</h4>", html.EscapeString(text))
+}
+
+func (m *htmlMessage) paragraph(text string) {
+	fmt.Fprintf(&m.plain, "%s\n", text)
+	fmt.Fprintf(&m.formatted, "<p>%s</p>", html.EscapeString(text))
+}
+
+func (m *htmlMessage) list(items [][2]string) {
+	m.formatted.WriteString("<ul>")
+	for _, item := range items {
+		if item[0] == "" {
+			fmt.Fprintf(&m.plain, "- %s\n", item[1])
+			fmt.Fprintf(&m.formatted, "<li>%s</li>", html.EscapeString(item[1]))
+			continue
+		}
+		fmt.Fprintf(&m.plain, "- %s: %s\n", item[0], item[1])
+		fmt.Fprintf(&m.formatted, "<li><strong>%s</strong>: %s</li>", html.EscapeString(item[0]), html.EscapeString(item[1]))
+	}
+	m.formatted.WriteString("</ul>")
+}
+
+func (m *htmlMessage) contact(contact *InputContactNode) {
+	items := [][2]string{}
+	for _, field := range filledContactFields(contact) {
+		items = append(items, [2]string{field.Label, field.Value})
+	}
+	m.list(items)
+}
+
+// newHTMLMessage renders data as html with lists for choices, selections and
+// contacts and tables for ratings, plus the equivalent plain text.
+func newHTMLMessage(ctx context.Context, data *InputFormFinished, adapter string) *htmlMessage {
+	message := &htmlMessage{}
+	fmt.Fprintf(&message.plain, "%s %s: %s\n", data.Title, data.LinkText, data.LinkUrl)
+	fmt.Fprintf(&message.formatted, "<p>%s <a href=\"%s\">%s</a></p>",
+		html.EscapeString(data.Title), html.EscapeString(data.LinkUrl), html.EscapeString(data.LinkText))
+
+	if data.FormTranslation != "" {
+		fmt.Fprintf(&message.plain, "\n%s\n", data.FormTranslation)
+		fmt.Fprintf(&message.formatted, "<h3>%s</h3>", html.EscapeString(data.FormTranslation))
+	}
+
+	if data.Contact != nil {
+		message.contact(data.Contact)
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		switch node.NodeType {
+		case 0:
+			message.heading(node.NodeTranslation)
+			message.formatted.WriteString("<ul>")
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				fmt.Fprintf(&message.plain, "- %s\n", element.Label)
+				fmt.Fprintf(&message.formatted, "<li>%s", html.EscapeString(element.Label))
+				for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+					if answer != "" {
+						fmt.Fprintf(&message.plain, "  > %s\n", answer)
+						fmt.Fprintf(&message.formatted, "<blockquote>%s</blockquote>", html.EscapeString(answer))
+					}
+				}
+				message.formatted.WriteString("</li>")
+			}
+			message.formatted.WriteString("</ul>")
+		case 1:
+			message.heading(node.NodeTranslation)
+			if node.SelectNode.Label != "" {
+				message.paragraph(node.SelectNode.Label)
+			}
+			items := make([][2]string, len(node.SelectNode.Selected))
+			for idx, selected := range node.SelectNode.Selected {
+				items[idx] = [2]string{"", selected}
+			}
+			message.list(items)
+		case 2:
+			message.heading(node.NodeTranslation)
+			message.contact(&node.ContactNode)
+		case 3:
+			message.heading(node.NodeTranslation)
+			if node.RatingNode.Label != "" {
+				message.paragraph(node.RatingNode.Label)
+			}
+			message.formatted.WriteString("<table><thead><tr><th>Label</th><th>Rating</th></tr></thead><tbody>")
+			for _, element := range node.RatingNode.Elements {
+				fmt.Fprintf(&message.plain, "- %s: %d/10\n", element.Label, element.Value)
+				fmt.Fprintf(&message.formatted, "<tr><td>%s</td><td>%d/10 ⭐</td></tr>", html.EscapeString(element.Label), element.Value)
+			}
+			message.formatted.WriteString("</tbody></table>")
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", adapter)
+			continue
+		}
+	}
+
+	return message
+}
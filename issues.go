@@ -0,0 +1,132 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const issueLabelLimit = 50
+
+// IssueConfig configures the GitHub and GitLab issue adapters. Title is a
+// text/template executed against the event input and defaults to
+// "{{.Title}}". Labels are added to every issue, next to the options
+// selected in select nodes.
+type IssueConfig struct {
+	Token  string
+	Title  string
+	Labels []string
+}
+
+type gitHubIssueData struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+type gitLabIssueData struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Labels      string `json:"labels,omitempty"`
+}
+
+// issueTitle executes the title template of cfg against data.
+func issueTitle(cfg *IssueConfig, data *InputFormFinished) (string, error) {
+	text := cfg.Title
+	if text == "" {
+		text = "{{.Title}}"
+	}
+	tmpl, err := template.New("title").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid issue title template: %w", err)
+	}
+	title := &strings.Builder{}
+	if err := tmpl.Execute(title, data); err != nil {
+		return "", fmt.Errorf("issue title template: %w", err)
+	}
+	return strings.Join(strings.Fields(title.String()), " "), nil
+}
+
+// issueLabels returns the configured labels followed by every selected
+// option of data, without duplicates.
+func issueLabels(cfg *IssueConfig, data *InputFormFinished) []string {
+	labels := []string{}
+	seen := map[string]bool{}
+	add := func(label string) {
+		label = truncateRunes(strings.TrimSpace(label), issueLabelLimit)
+		if label == "" || seen[label] {
+			return
+		}
+		seen[label] = true
+		labels = append(labels, label)
+	}
+	for _, label := range cfg.Labels {
+		add(label)
+	}
+	for _, node := range data.Nodes {
+		if node.NodeType == 1 {
+			for _, selected := range node.SelectNode.Selected {
+				add(selected)
+			}
+		}
+	}
+	return labels
+}
+
+func issueBody(ctx context.Context, data *InputFormFinished, adapter string) string {
+	return fmt.Sprintf("%s [%s](%s)\n\n%s", data.Title, data.LinkText, data.LinkUrl, markdownMessage(ctx, data, adapter))
+}
+
+func gitHubIssue(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*IssueConfig)
+	if !ok || cfg == nil || cfg.Token == "" {
+		return nil, errors.New("github token not configured")
+	}
+	title, err := issueTitle(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Webhook{
+		Data: gitHubIssueData{
+			Title:  title,
+			Body:   issueBody(ctx, data, "github"),
+			Labels: issueLabels(cfg, data),
+		},
+		Headers: map[string][]string{
+			"Authorization":        {"Bearer " + cfg.Token},
+			"Accept":               {"application/vnd.github+json"},
+			"X-GitHub-Api-Version": {"2022-11-28"},
+		},
+	}, nil
+}
+
+func gitLabIssue(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*IssueConfig)
+	if !ok || cfg == nil || cfg.Token == "" {
+		return nil, errors.New("gitlab token not configured")
+	}
+	title, err := issueTitle(cfg, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// gitlab takes labels as a comma separated list
+	labels := issueLabels(cfg, data)
+	for idx, label := range labels {
+		labels[idx] = strings.ReplaceAll(label, ",", " ")
+	}
+
+	return &Webhook{
+		Data: gitLabIssueData{
+			Title:       title,
+			Description: issueBody(ctx, data, "gitlab"),
+			Labels:      strings.Join(labels, ","),
+		},
+		Headers: map[string][]string{
+			"PRIVATE-TOKEN": {cfg.Token},
+		},
+	}, nil
+}
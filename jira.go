@@ -0,0 +1,267 @@
+package integrations
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const jiraSummaryLimit = 255
+
+// JiraConfig configures the Jira adapter. CustomFields maps the Relation of
+// a node to the id of the custom field (e.g. "customfield_10042") that
+// receives its answer: ratings with a single element as number, selections
+// as multi select options and everything else as text. Email and APIToken
+// are optional and sent as basic authentication.
+type JiraConfig struct {
+	ProjectKey   string
+	IssueType    string
+	Labels       []string
+	CustomFields map[int64]string
+	Email        string
+	APIToken     string
+}
+
+type jiraData struct {
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// adfNode is a node of the Atlassian Document Format,
+// https://developer.atlassian.com/cloud/jira/platform/apis/document/structure/
+type adfNode struct {
+	Type    string                 `json:"type"`
+	Version int                    `json:"version,omitempty"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+	Content []adfNode              `json:"content,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Marks   []adfMark              `json:"marks,omitempty"`
+}
+
+type adfMark struct {
+	Type  string                 `json:"type"`
+	Attrs map[string]interface{} `json:"attrs,omitempty"`
+}
+
+func adfText(text string, marks ...adfMark) []adfNode {
+	// adf rejects empty text nodes
+	if text == "" {
+		return nil
+	}
+	return []adfNode{{Type: "text", Text: text, Marks: marks}}
+}
+
+func adfParagraph(content ...adfNode) adfNode {
+	return adfNode{Type: "paragraph", Content: content}
+}
+
+func adfHeading(level int, text string) adfNode {
+	return adfNode{Type: "heading", Attrs: map[string]interface{}{"level": level}, Content: adfText(text)}
+}
+
+func adfBulletList(items [][]adfNode) adfNode {
+	list := adfNode{Type: "bulletList", Content: []adfNode{}}
+	for _, item := range items {
+		list.Content = append(list.Content, adfNode{
+			Type:    "listItem",
+			Content: []adfNode{adfParagraph(item...)},
+		})
+	}
+	return list
+}
+
+func adfContactList(contact *InputContactNode) adfNode {
+	items := [][]adfNode{}
+	for _, field := range filledContactFields(contact) {
+		items = append(items, append(adfText(field.Label+": ", adfMark{Type: "strong"}), adfText(field.Value)...))
+	}
+	return adfBulletList(items)
+}
+
+// adfContactPanel wraps the contact list in an info panel, or returns no
+// nodes if all fields are empty since Jira rejects empty lists.
+func adfContactPanel(contact *InputContactNode) []adfNode {
+	list := adfContactList(contact)
+	if len(list.Content) == 0 {
+		return nil
+	}
+	return []adfNode{{
+		Type:    "panel",
+		Attrs:   map[string]interface{}{"panelType": "info"},
+		Content: []adfNode{list},
+	}}
+}
+
+// jiraCustomFieldValue converts the answer of node into the value of the
+// custom field it is mapped to.
+func jiraCustomFieldValue(node InputFormFinishedNode) interface{} {
+	switch node.NodeType {
+	case 0:
+		lines := []string{}
+		for _, element := range node.ChoiceNode.Elements {
+			answers := []string{element.Label}
+			for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+				if answer != "" {
+					answers = append(answers, answer)
+				}
+			}
+			lines = append(lines, strings.Join(answers, ": "))
+		}
+		return strings.Join(lines, "\n")
+	case 1:
+		options := make([]map[string]string, len(node.SelectNode.Selected))
+		for idx, selected := range node.SelectNode.Selected {
+			options[idx] = map[string]string{"value": selected}
+		}
+		return options
+	case 2:
+		contact := node.ContactNode
+		return strings.TrimSpace(strings.Join([]string{
+			contact.Firstname + " " + contact.Lastname,
+			contact.Email,
+			contact.Company,
+			contact.Phone,
+			contact.Details,
+		}, "\n"))
+	case 3:
+		if len(node.RatingNode.Elements) == 1 {
+			return node.RatingNode.Elements[0].Value
+		}
+		lines := []string{}
+		for _, element := range node.RatingNode.Elements {
+			lines = append(lines, fmt.Sprintf("%s: %d/10", element.Label, element.Value))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return nil
+}
+
+func jira(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*JiraConfig)
+	if !ok || cfg == nil || cfg.ProjectKey == "" {
+		return nil, errors.New("jira project key not configured")
+	}
+	content := []adfNode{
+		adfParagraph(append(
+			adfText(data.Title+" "),
+			adfText(data.LinkText, adfMark{Type: "link", Attrs: map[string]interface{}{"href": data.LinkUrl}})...,
+		)...),
+	}
+	if data.FormTranslation != "" {
+		content = append(content, adfHeading(2, data.FormTranslation))
+	}
+
+	if data.Contact != nil {
+		content = append(content, adfContactPanel(data.Contact)...)
+	}
+
+	fields := map[string]interface{}{}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		if fieldID, ok := cfg.CustomFields[node.Relation]; ok {
+			// unknown node types leave the field unset rather than null
+			if value := jiraCustomFieldValue(node); value != nil {
+				fields[fieldID] = value
+			}
+		}
+		switch node.NodeType {
+		case 0:
+			content = append(content, adfHeading(3, node.NodeTranslation))
+			items := [][]adfNode{}
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				item := adfText(element.Label, adfMark{Type: "strong"})
+				for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+					if answer != "" {
+						item = append(item, adfNode{Type: "hardBreak"})
+						item = append(item, adfText(answer)...)
+					}
+				}
+				items = append(items, item)
+			}
+			content = append(content, adfBulletList(items))
+		case 1:
+			content = append(content, adfHeading(3, node.NodeTranslation))
+			if node.SelectNode.Label != "" {
+				content = append(content, adfParagraph(adfText(node.SelectNode.Label)...))
+			}
+			items := [][]adfNode{}
+			for _, selected := range node.SelectNode.Selected {
+				items = append(items, adfText(selected))
+			}
+			content = append(content, adfBulletList(items))
+		case 2:
+			content = append(content, adfHeading(3, node.NodeTranslation))
+			content = append(content, adfContactPanel(&node.ContactNode)...)
+		case 3:
+			content = append(content, adfHeading(3, node.NodeTranslation))
+			if node.RatingNode.Label != "" {
+				content = append(content, adfParagraph(adfText(node.RatingNode.Label)...))
+			}
+			rows := []adfNode{
+				{
+					Type: "tableRow",
+					Content: []adfNode{
+						{Type: "tableHeader", Content: []adfNode{adfParagraph(adfText("Label")...)}},
+						{Type: "tableHeader", Content: []adfNode{adfParagraph(adfText("Rating")...)}},
+					},
+				},
+			}
+			for _, element := range node.RatingNode.Elements {
+				rows = append(rows, adfNode{
+					Type: "tableRow",
+					Content: []adfNode{
+						{Type: "tableCell", Content: []adfNode{adfParagraph(adfText(element.Label)...)}},
+						{Type: "tableCell", Content: []adfNode{adfParagraph(adfText(fmt.Sprintf("%d/10", element.Value))...)}},
+					},
+				})
+			}
+			content = append(content, adfNode{Type: "table", Content: rows})
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "jira")
+			continue
+		}
+	}
+
+	// list nodes without items are rejected as well
+	description := adfNode{Type: "doc", Version: 1, Content: []adfNode{}}
+	for _, block := range content {
+		if block.Type == "bulletList" && len(block.Content) == 0 {
+			continue
+		}
+		description.Content = append(description.Content, block)
+	}
+
+	issueType := cfg.IssueType
+	if issueType == "" {
+		issueType = "Task"
+	}
+	fields["project"] = map[string]string{"key": cfg.ProjectKey}
+	fields["issuetype"] = map[string]string{"name": issueType}
+	fields["summary"] = truncateRunes(strings.ReplaceAll(data.Title, "\n", " "), jiraSummaryLimit)
+	fields["description"] = description
+	if len(cfg.Labels) > 0 {
+		fields["labels"] = cfg.Labels
+	}
+
+	var headers map[string][]string
+	if cfg.Email != "" && cfg.APIToken != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(cfg.Email + ":" + cfg.APIToken))
+		headers = map[string][]string{
+			"Authorization": {"Basic " + credentials},
+		}
+	}
+
+	return &Webhook{
+		Data: jiraData{
+			Fields: fields,
+		},
+		Headers: headers,
+	}, nil
+}
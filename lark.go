@@ -0,0 +1,207 @@
+package integrations
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LarkConfig configures the Lark/Feishu adapter. Secret is the signing
+// secret of a custom bot with signature verification enabled.
+type LarkConfig struct {
+	Secret string
+}
+
+type larkData struct {
+	Timestamp string   `json:"timestamp,omitempty"`
+	Sign      string   `json:"sign,omitempty"`
+	MsgType   string   `json:"msg_type"`
+	Card      larkCard `json:"card"`
+}
+
+type larkCard struct {
+	Config   larkCardConfig    `json:"config"`
+	Header   larkCardHeader    `json:"header"`
+	Elements []larkCardElement `json:"elements"`
+}
+
+type larkCardConfig struct {
+	WideScreenMode bool `json:"wide_screen_mode"`
+}
+
+type larkCardHeader struct {
+	Title    larkText `json:"title"`
+	Template string   `json:"template"`
+}
+
+type larkText struct {
+	Tag     string `json:"tag"`
+	Content string `json:"content"`
+}
+
+type larkCardElement struct {
+	Tag     string           `json:"tag"`
+	Text    *larkText        `json:"text,omitempty"`
+	Fields  []larkCardField  `json:"fields,omitempty"`
+	Actions []larkCardAction `json:"actions,omitempty"`
+}
+
+type larkCardField struct {
+	IsShort bool     `json:"is_short"`
+	Text    larkText `json:"text"`
+}
+
+type larkCardAction struct {
+	Tag  string   `json:"tag"`
+	Text larkText `json:"text"`
+	URL  string   `json:"url"`
+	Type string   `json:"type"`
+}
+
+// larkSign computes the signature of custom bots, an HMAC-SHA256 keyed with
+// "timestamp\nsecret" over an empty message.
+func larkSign(timestamp int64, secret string) string {
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(timestamp, 10)+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func larkMarkdown(content string) *larkText {
+	return &larkText{
+		Tag:     "lark_md",
+		Content: content,
+	}
+}
+
+func larkContactFields(contact *InputContactNode) []larkCardField {
+	fields := []larkCardField{}
+	for _, field := range filledContactFields(contact) {
+		fields = append(fields, larkCardField{
+			IsShort: true,
+			Text:    *larkMarkdown(fmt.Sprintf("**%s**\n%s", field.Label, field.Value)),
+		})
+	}
+	return fields
+}
+
+func lark(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, _ := config.(*LarkConfig)
+	elements := []larkCardElement{}
+	if data.FormTranslation != "" {
+		elements = append(elements, larkCardElement{
+			Tag:  "div",
+			Text: larkMarkdown(fmt.Sprintf("**%s**", data.FormTranslation)),
+		})
+	}
+
+	if data.Contact != nil {
+		elements = append(elements, larkCardElement{
+			Tag:    "div",
+			Fields: larkContactFields(data.Contact),
+		})
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		content := &strings.Builder{}
+		fmt.Fprintf(content, "**%s**\n", node.NodeTranslation)
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				fmt.Fprintf(content, "- %s\n", element.Label)
+				if element.AnswerShort != "" {
+					fmt.Fprintf(content, "  %s\n", element.AnswerShort)
+				}
+				if element.AnswerLong != "" {
+					fmt.Fprintf(content, "  %s\n", element.AnswerLong)
+				}
+			}
+		case 1:
+			if node.SelectNode.Label != "" {
+				fmt.Fprintf(content, "%s\n", node.SelectNode.Label)
+			}
+			for _, selected := range node.SelectNode.Selected {
+				fmt.Fprintf(content, "- %s\n", selected)
+			}
+		case 2:
+			elements = append(elements, larkCardElement{Tag: "hr"})
+			elements = append(elements, larkCardElement{
+				Tag:  "div",
+				Text: larkMarkdown(strings.TrimSpace(content.String())),
+			})
+			elements = append(elements, larkCardElement{
+				Tag:    "div",
+				Fields: larkContactFields(&node.ContactNode),
+			})
+			continue
+		case 3:
+			if node.RatingNode.Label != "" {
+				fmt.Fprintf(content, "%s\n", node.RatingNode.Label)
+			}
+			for _, element := range node.RatingNode.Elements {
+				fmt.Fprintf(content, "- %s: **%d/10** ⭐\n", element.Label, element.Value)
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "lark")
+			continue
+		}
+		elements = append(elements, larkCardElement{Tag: "hr"})
+		elements = append(elements, larkCardElement{
+			Tag:  "div",
+			Text: larkMarkdown(strings.TrimSpace(content.String())),
+		})
+	}
+
+	if data.LinkUrl != "" {
+		elements = append(elements, larkCardElement{
+			Tag: "action",
+			Actions: []larkCardAction{
+				{
+					Tag: "button",
+					Text: larkText{
+						Tag:     "plain_text",
+						Content: data.LinkText,
+					},
+					URL:  data.LinkUrl,
+					Type: "primary",
+				},
+			},
+		})
+	}
+
+	payload := larkData{
+		MsgType: "interactive",
+		Card: larkCard{
+			Config: larkCardConfig{
+				WideScreenMode: true,
+			},
+			Header: larkCardHeader{
+				Title: larkText{
+					Tag:     "plain_text",
+					Content: data.Title,
+				},
+				Template: "blue",
+			},
+			Elements: elements,
+		},
+	}
+	if cfg != nil && cfg.Secret != "" {
+		timestamp := time.Now().Unix()
+		payload.Timestamp = strconv.FormatInt(timestamp, 10)
+		payload.Sign = larkSign(timestamp, cfg.Secret)
+	}
+
+	return &Webhook{
+		Data:    payload,
+		Headers: nil,
+	}, nil
+}
@@ -1,12 +1,16 @@
 package integrations
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
 )
 
 type IntegrationInterface interface {
 	MapWebhook(input interface{}, adapterType IntegrationType, eventType EventType) (*Webhook, error)
+	MapWebhookWithConfig(input interface{}, adapterType IntegrationType, eventType EventType, config interface{}) (*Webhook, error)
+	MapWebhookContext(ctx context.Context, input interface{}, adapterType IntegrationType, eventType EventType, config interface{}) (*Webhook, error)
 	GetIntegrationDetails() IntegrationDetailMap
 }
 
@@ -18,14 +22,14 @@ type adapterData struct{}
 
 var _ IntegrationInterface = &adapterService{}
 
-type adapterDetail struct {
+type AdapterDetail struct {
 	Name  string
 	Icon  string
 	Color string
 	Help  string
 }
 
-type IntegrationDetailMap map[IntegrationType]adapterDetail
+type IntegrationDetailMap map[IntegrationType]AdapterDetail
 
 type IntegrationType int64
 
@@ -80,29 +84,71 @@ type InputChoiceNode struct {
 
 type EventType string
 
+func (data *InputFormFinished) EventType() EventType {
+	return EventFormFinished
+}
+
 type Webhook struct {
+	// Method is the HTTP method of the request, empty means POST.
+	Method string
+	// Path is appended to the path of the webhook url, e.g. a transaction
+	// id that has to be unique per event.
+	Path    string
 	Data    interface{}
 	Headers map[string][]string
+	// Query holds parameters to append to the webhook url, e.g. request
+	// signatures of platforms that expect them there.
+	Query url.Values
+	// Followups are sent in order after this webhook, for platforms that
+	// require long content to be split into several messages.
+	Followups []*Webhook
+	// Then builds a request that depends on the response to this one, e.g.
+	// a note that refers to the id of a created contact. It is called once
+	// this webhook and its Followups succeeded, a nil Webhook ends the chain.
+	Then func(result *DeliveryResult) (*Webhook, error)
 }
 
 const (
-	IntegrationGeneric    IntegrationType = 0
-	IntegrationMattermost IntegrationType = 1
-	IntegrationSlack      IntegrationType = 2
-	IntegrationNtfy       IntegrationType = 3
-	IntegrationTeams      IntegrationType = 4
-
-	MinTypeID int64 = int64(IntegrationGeneric)
-	MaxTypeID int64 = int64(IntegrationTeams)
+	IntegrationGeneric        IntegrationType = 0
+	IntegrationMattermost     IntegrationType = 1
+	IntegrationSlack          IntegrationType = 2
+	IntegrationNtfy           IntegrationType = 3
+	IntegrationTeams          IntegrationType = 4
+	IntegrationDiscord        IntegrationType = 5
+	IntegrationGoogleChat     IntegrationType = 6
+	IntegrationRocketChat     IntegrationType = 7
+	IntegrationZulip          IntegrationType = 8
+	IntegrationTelegram       IntegrationType = 9
+	IntegrationMatrix         IntegrationType = 10
+	IntegrationEmail          IntegrationType = 11
+	IntegrationTeamsWorkflows IntegrationType = 12
+	IntegrationWebex          IntegrationType = 13
+	IntegrationLark           IntegrationType = 14
+	IntegrationDingTalk       IntegrationType = 15
+	IntegrationWeCom          IntegrationType = 16
+	IntegrationPushover       IntegrationType = 17
+	IntegrationGotify         IntegrationType = 18
+	IntegrationJira           IntegrationType = 19
+	IntegrationGitHubIssue    IntegrationType = 20
+	IntegrationGitLabIssue    IntegrationType = 21
+	IntegrationHubSpot        IntegrationType = 22
+	IntegrationPipedrive      IntegrationType = 23
+	IntegrationZendesk        IntegrationType = 24
+	IntegrationFreshdesk      IntegrationType = 25
+	IntegrationNotion         IntegrationType = 26
+	IntegrationAirtable       IntegrationType = 27
+	IntegrationGoogleSheets   IntegrationType = 28
+	IntegrationCloudEvents    IntegrationType = 29
+	IntegrationFlat           IntegrationType = 30
 
 	EventFormFinished EventType = "form.finished"
 )
 
-var adapterDetails = IntegrationDetailMap{
-	// IntegrationGeneric: {
-	// 	Name: "Generic Webhook",
-	// 	Icon: "logos:webhooks",
-	// },
+var builtinAdapterDetails = IntegrationDetailMap{
+	IntegrationGeneric: {
+		Name: "Generic Webhook",
+		Icon: "logos:webhooks",
+	},
 	IntegrationMattermost: {
 		Name: "Mattermost",
 		Icon: "logos:mattermost-icon",
@@ -113,16 +159,146 @@ var adapterDetails = IntegrationDetailMap{
 		Icon: "logos:slack-icon",
 		Help: "https://api.slack.com/messaging/webhooks",
 	},
-	// IntegrationNtfy: {
-	// 	Name:  "Ntfy",
-	// 	Icon:  "simple-icons:ntfy",
-	// 	Color: "#10b981",
-	// },
+	IntegrationNtfy: {
+		Name:  "Ntfy",
+		Icon:  "simple-icons:ntfy",
+		Color: "#10b981",
+		Help:  "https://docs.ntfy.sh/publish/",
+	},
 	IntegrationTeams: {
 		Name: "Teams",
 		Icon: "logos:microsoft-teams",
 		Help: "https://learn.microsoft.com/en-us/microsoftteams/platform/webhooks-and-connectors/how-to/add-incoming-webhook",
 	},
+	IntegrationDiscord: {
+		Name: "Discord",
+		Icon: "logos:discord-icon",
+		Help: "https://support.discord.com/hc/en-us/articles/228383668-Intro-to-Webhooks",
+	},
+	IntegrationGoogleChat: {
+		Name: "Google Chat",
+		Icon: "logos:google-chat",
+		Help: "https://developers.google.com/workspace/chat/quickstart/webhooks",
+	},
+	IntegrationRocketChat: {
+		Name: "Rocket.Chat",
+		Icon: "logos:rocket-chat-icon",
+		Help: "https://docs.rocket.chat/use-rocket.chat/workspace-administration/integrations",
+	},
+	IntegrationZulip: {
+		Name: "Zulip",
+		Icon: "simple-icons:zulip",
+		Help: "https://zulip.com/api/send-message",
+	},
+	IntegrationTelegram: {
+		Name: "Telegram",
+		Icon: "logos:telegram",
+		Help: "https://core.telegram.org/bots/api#sendmessage",
+	},
+	IntegrationMatrix: {
+		Name: "Matrix",
+		Icon: "simple-icons:matrix",
+		Help: "https://spec.matrix.org/latest/client-server-api/#put_matrixclientv3roomsroomidsendeventtypetxnid",
+	},
+	IntegrationEmail: {
+		Name: "Email",
+		Icon: "mdi:email-outline",
+	},
+	IntegrationTeamsWorkflows: {
+		Name: "Teams Workflows",
+		Icon: "logos:microsoft-teams",
+		Help: "https://support.microsoft.com/en-us/office/create-incoming-webhooks-with-workflows-for-microsoft-teams-8ae491c7-0394-4861-ba59-055e33f75498",
+	},
+	IntegrationWebex: {
+		Name: "Webex",
+		Icon: "logos:webex",
+		Help: "https://apphub.webex.com/applications/incoming-webhooks-cisco-systems-38054-23307-75252",
+	},
+	IntegrationLark: {
+		Name: "Lark",
+		Icon: "simple-icons:lark",
+		Help: "https://open.larksuite.com/document/client-docs/bot-v3/add-custom-bot",
+	},
+	IntegrationDingTalk: {
+		Name: "DingTalk",
+		Icon: "ant-design:dingtalk-circle-filled",
+		Help: "https://open.dingtalk.com/document/robots/custom-robot-access",
+	},
+	IntegrationWeCom: {
+		Name: "WeCom",
+		Icon: "simple-icons:wechat",
+		Help: "https://developer.work.weixin.qq.com/document/path/91770",
+	},
+	IntegrationPushover: {
+		Name: "Pushover",
+		Icon: "simple-icons:pushover",
+		Help: "https://pushover.net/api",
+	},
+	IntegrationGotify: {
+		Name: "Gotify",
+		Icon: "simple-icons:gotify",
+		Help: "https://gotify.net/docs/pushmsg",
+	},
+	IntegrationJira: {
+		Name: "Jira",
+		Icon: "logos:jira",
+		Help: "https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issues/#api-rest-api-3-issue-post",
+	},
+	IntegrationGitHubIssue: {
+		Name: "GitHub Issue",
+		Icon: "logos:github-icon",
+		Help: "https://docs.github.com/en/rest/issues/issues#create-an-issue",
+	},
+	IntegrationGitLabIssue: {
+		Name: "GitLab Issue",
+		Icon: "logos:gitlab",
+		Help: "https://docs.gitlab.com/ee/api/issues.html#new-issue",
+	},
+	IntegrationHubSpot: {
+		Name: "HubSpot",
+		Icon: "logos:hubspot",
+		Help: "https://developers.hubspot.com/docs/api/crm/contacts",
+	},
+	IntegrationPipedrive: {
+		Name: "Pipedrive",
+		Icon: "simple-icons:pipedrive",
+		Help: "https://developers.pipedrive.com/docs/api/v1/Leads#addLead",
+	},
+	IntegrationZendesk: {
+		Name: "Zendesk",
+		Icon: "simple-icons:zendesk",
+		Help: "https://developer.zendesk.com/api-reference/ticketing/tickets/tickets/#create-ticket",
+	},
+	IntegrationFreshdesk: {
+		Name: "Freshdesk",
+		Icon: "simple-icons:freshdesk",
+		Help: "https://developers.freshdesk.com/api/#create_ticket",
+	},
+	IntegrationNotion: {
+		Name: "Notion",
+		Icon: "logos:notion-icon",
+		Help: "https://developers.notion.com/reference/post-page",
+	},
+	IntegrationAirtable: {
+		Name: "Airtable",
+		Icon: "logos:airtable",
+		Help: "https://airtable.com/developers/web/api/create-records",
+	},
+	IntegrationGoogleSheets: {
+		Name: "Google Sheets",
+		Icon: "logos:google-sheets",
+		Help: "https://developers.google.com/sheets/api/reference/rest/v4/spreadsheets.values/append",
+	},
+	IntegrationCloudEvents: {
+		Name: "CloudEvents",
+		Icon: "simple-icons:cloudevents",
+		Help: "https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md",
+	},
+	IntegrationFlat: {
+		Name: "Zapier / Make / n8n",
+		Icon: "logos:zapier-icon",
+		Help: "https://help.zapier.com/hc/en-us/articles/8496288690317-Trigger-Zaps-from-webhooks",
+	},
 }
 
 func NewIntegration() *adapterService {
@@ -131,24 +307,67 @@ func NewIntegration() *adapterService {
 	}
 }
 
+// GetIntegrationDetails returns the details of all registered adapters.
 func (ad *adapterData) GetIntegrationDetails() IntegrationDetailMap {
-	return adapterDetails
+	details := IntegrationDetailMap{}
+	for _, adapterType := range Registered() {
+		if _, detail, ok := Lookup(adapterType); ok {
+			details[adapterType] = detail
+		}
+	}
+	return details
 }
 
-var sendWebhookMap = map[IntegrationType]func(input interface{}, eventType EventType) (*Webhook, error){
-	// IntegrationGeneric:    generic,
-	IntegrationMattermost: mattermost,
-	IntegrationSlack:      slack,
-	// IntegrationNtfy:       ntfy,
-	IntegrationTeams: teams,
+// builtinAdapters are registered on init, together with their
+// builtinAdapterDetails.
+var builtinAdapters = map[IntegrationType]Adapter{
+	IntegrationGeneric:        NewAdapter("generic", generic),
+	IntegrationMattermost:     NewAdapter("mattermost", mattermost),
+	IntegrationSlack:          NewAdapter("slack", slack),
+	IntegrationNtfy:           NewAdapter("ntfy", ntfy),
+	IntegrationTeams:          NewAdapter("teams", teams),
+	IntegrationDiscord:        NewAdapter("discord", discord),
+	IntegrationGoogleChat:     NewAdapter("googlechat", googleChat),
+	IntegrationRocketChat:     NewAdapter("rocketchat", rocketChat),
+	IntegrationZulip:          NewAdapter("zulip", zulip),
+	IntegrationTelegram:       NewAdapter("telegram", telegram),
+	IntegrationMatrix:         NewAdapter("matrix", matrix),
+	IntegrationEmail:          NewAdapter("email", email),
+	IntegrationTeamsWorkflows: NewAdapter("teamsworkflows", teamsWorkflows),
+	IntegrationWebex:          NewAdapter("webex", webex),
+	IntegrationLark:           NewAdapter("lark", lark),
+	IntegrationDingTalk:       NewAdapter("dingtalk", dingTalk),
+	IntegrationWeCom:          NewAdapter("wecom", wecom),
+	IntegrationPushover:       NewAdapter("pushover", pushover),
+	IntegrationGotify:         NewAdapter("gotify", gotify),
+	IntegrationJira:           NewAdapter("jira", jira),
+	IntegrationGitHubIssue:    NewAdapter("github", gitHubIssue),
+	IntegrationGitLabIssue:    NewAdapter("gitlab", gitLabIssue),
+	IntegrationHubSpot:        NewAdapter("hubspot", hubSpot),
+	IntegrationPipedrive:      NewAdapter("pipedrive", pipedrive),
+	IntegrationZendesk:        NewAdapter("zendesk", zendesk),
+	IntegrationFreshdesk:      NewAdapter("freshdesk", freshdesk),
+	IntegrationNotion:         NewAdapter("notion", notion),
+	IntegrationAirtable:       NewAdapter("airtable", airtable),
+	IntegrationGoogleSheets:   NewAdapter("sheets", sheets),
+	IntegrationCloudEvents:    NewAdapter("cloudevents", cloudEvents),
+	IntegrationFlat:           NewAdapter("flat", flat),
 }
 
 func (ad *adapterData) MapWebhook(input interface{}, adapterType IntegrationType, eventType EventType) (*Webhook, error) {
+	return ad.MapWebhookWithConfig(input, adapterType, eventType, nil)
+}
+
+func (ad *adapterData) MapWebhookWithConfig(input interface{}, adapterType IntegrationType, eventType EventType, config interface{}) (*Webhook, error) {
+	return ad.MapWebhookContext(context.Background(), input, adapterType, eventType, config)
+}
+
+func (ad *adapterData) MapWebhookContext(ctx context.Context, input interface{}, adapterType IntegrationType, eventType EventType, config interface{}) (*Webhook, error) {
 	if input == nil {
 		return nil, errors.New("input not defined")
 	}
-	if sendWebhookFunc, ok := sendWebhookMap[adapterType]; ok {
-		return sendWebhookFunc(input, eventType)
+	if adapter, _, ok := Lookup(adapterType); ok {
+		return adapter(ctx, input, eventType, config)
 	} else {
 		return nil, errors.New(fmt.Sprint("map function not defined, type: ", adapterType))
 	}
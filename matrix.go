@@ -0,0 +1,55 @@
+package integrations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// MatrixConfig configures the Matrix adapter. AccessToken is the token of
+// the user or appservice the m.room.message event is sent as. The webhook
+// url is https://{homeserver}/_matrix/client/v3/rooms/{roomId}/send/m.room.message,
+// a new transaction id is appended to it for every event.
+type MatrixConfig struct {
+	AccessToken string
+}
+
+type matrixData struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+func matrix(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*MatrixConfig)
+	if !ok || cfg == nil || cfg.AccessToken == "" {
+		return nil, errors.New("matrix access token not configured")
+	}
+	message := newHTMLMessage(ctx, data, "matrix")
+
+	// homeservers drop events that reuse a transaction id
+	txnID := make([]byte, 16)
+	if _, err := rand.Read(txnID); err != nil {
+		return nil, err
+	}
+
+	// the transaction id stays the same across retries, which makes them
+	// idempotent
+	return &Webhook{
+		Method: http.MethodPut,
+		Path:   hex.EncodeToString(txnID),
+		Data: matrixData{
+			MsgType:       "m.text",
+			Body:          strings.TrimSpace(message.plain.String()),
+			Format:        "org.matrix.custom.html",
+			FormattedBody: message.formatted.String(),
+		},
+		Headers: map[string][]string{
+			"Authorization": {"Bearer " + cfg.AccessToken},
+		},
+	}, nil
+}
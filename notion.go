@@ -0,0 +1,261 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// limits documented at https://developers.notion.com/reference/request-limits
+const (
+	notionTextLimit     = 2000
+	notionChildrenLimit = 100
+)
+
+type notionData struct {
+	Parent     notionParent           `json:"parent"`
+	Properties map[string]interface{} `json:"properties"`
+	Children   []notionBlock          `json:"children,omitempty"`
+}
+
+type notionParent struct {
+	DatabaseID string `json:"database_id"`
+}
+
+type notionRichText struct {
+	Type        string             `json:"type"`
+	Text        notionTextBody     `json:"text"`
+	Annotations *notionAnnotations `json:"annotations,omitempty"`
+}
+
+type notionAnnotations struct {
+	Bold bool `json:"bold"`
+}
+
+type notionTextBody struct {
+	Content string `json:"content"`
+}
+
+// notionBlock holds the block specific object under the key named by Type,
+// e.g. {"type": "paragraph", "paragraph": {...}}.
+type notionBlock map[string]interface{}
+
+// notionText splits text into rich text objects within the length limit of
+// each object.
+func notionText(text string) []notionRichText {
+	richText := []notionRichText{}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		size := min(len(runes), notionTextLimit)
+		richText = append(richText, notionRichText{Type: "text", Text: notionTextBody{Content: string(runes[:size])}})
+		runes = runes[size:]
+	}
+	return richText
+}
+
+// notionSection is the content of a single node, as blocks and as plain
+// lines for pages that would exceed the block limit otherwise.
+type notionSection struct {
+	title  string
+	blocks []notionBlock
+	lines  []string
+}
+
+// compact renders section as a single paragraph with a bold title.
+func (section notionSection) compact() notionBlock {
+	richText := []notionRichText{}
+	if section.title != "" {
+		richText = append(richText, notionRichText{
+			Type:        "text",
+			Text:        notionTextBody{Content: truncateRunes(section.title, notionTextLimit-1) + "\n"},
+			Annotations: &notionAnnotations{Bold: true},
+		})
+	}
+	richText = append(richText, notionText(strings.Join(section.lines, "\n"))...)
+	return notionBlock{
+		"object":    "block",
+		"type":      "paragraph",
+		"paragraph": map[string]interface{}{"rich_text": richText},
+	}
+}
+
+func notionTextBlock(blockType string, text string) notionBlock {
+	return notionBlock{
+		"object":  "block",
+		"type":    blockType,
+		blockType: map[string]interface{}{"rich_text": notionText(text)},
+	}
+}
+
+func notionTable(header []string, rows [][]string) notionBlock {
+	children := []notionBlock{}
+	for _, row := range append([][]string{header}, rows...) {
+		cells := make([][]notionRichText, len(row))
+		for idx, cell := range row {
+			cells[idx] = notionText(cell)
+		}
+		children = append(children, notionBlock{
+			"type":      "table_row",
+			"table_row": map[string]interface{}{"cells": cells},
+		})
+	}
+	return notionBlock{
+		"object": "block",
+		"type":   "table",
+		"table": map[string]interface{}{
+			"table_width":       len(header),
+			"has_column_header": true,
+			"children":          children,
+		},
+	}
+}
+
+func notionContactSection(title string, contact *InputContactNode) notionSection {
+	section := notionSection{title: title}
+	if title != "" {
+		section.blocks = append(section.blocks, notionTextBlock("heading_3", title))
+	}
+	for _, field := range filledContactFields(contact) {
+		section.blocks = append(section.blocks, notionTextBlock("bulleted_list_item", field.Label+": "+field.Value))
+		section.lines = append(section.lines, field.Label+": "+field.Value)
+	}
+	return section
+}
+
+func notion(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*DatabaseConfig)
+	if !ok || cfg == nil || cfg.Token == "" || cfg.DatabaseID == "" {
+		return nil, errors.New("notion token or database not configured")
+	}
+	titleProperty := cfg.TitleProperty
+	if titleProperty == "" {
+		titleProperty = "Name"
+	}
+	properties := map[string]interface{}{
+		titleProperty: map[string]interface{}{"title": notionText(data.Title)},
+	}
+	for column, value := range databaseRow(cfg, data) {
+		switch value.Type {
+		case databaseValueNumber:
+			properties[column] = map[string]interface{}{"number": value.Number}
+		case databaseValueMultiSelect:
+			options := []map[string]string{}
+			for _, selected := range value.MultiSelect {
+				// notion rejects option names containing commas
+				options = append(options, map[string]string{"name": strings.ReplaceAll(selected, ",", " ")})
+			}
+			properties[column] = map[string]interface{}{"multi_select": options}
+		case databaseValueEmail:
+			properties[column] = map[string]interface{}{"email": value.Text}
+		default:
+			properties[column] = map[string]interface{}{"rich_text": notionText(value.Text)}
+		}
+	}
+
+	children := []notionBlock{}
+	if data.LinkUrl != "" {
+		children = append(children, notionBlock{
+			"object": "block",
+			"type":   "paragraph",
+			"paragraph": map[string]interface{}{
+				"rich_text": []map[string]interface{}{
+					{
+						"type": "text",
+						"text": map[string]interface{}{
+							"content": data.LinkText,
+							"link":    map[string]string{"url": data.LinkUrl},
+						},
+					},
+				},
+			},
+		})
+	}
+	sections := []notionSection{}
+	if data.Contact != nil {
+		sections = append(sections, notionContactSection("", data.Contact))
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		section := notionSection{
+			title:  node.NodeTranslation,
+			blocks: []notionBlock{notionTextBlock("heading_3", node.NodeTranslation)},
+		}
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				section.blocks = append(section.blocks, notionTextBlock("bulleted_list_item", element.Label))
+				section.lines = append(section.lines, "• "+element.Label)
+				for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+					if answer != "" {
+						section.blocks = append(section.blocks, notionTextBlock("quote", answer))
+						section.lines = append(section.lines, answer)
+					}
+				}
+			}
+		case 1:
+			if node.SelectNode.Label != "" {
+				section.blocks = append(section.blocks, notionTextBlock("paragraph", node.SelectNode.Label))
+				section.lines = append(section.lines, node.SelectNode.Label)
+			}
+			for _, selected := range node.SelectNode.Selected {
+				section.blocks = append(section.blocks, notionTextBlock("bulleted_list_item", selected))
+				section.lines = append(section.lines, "• "+selected)
+			}
+		case 2:
+			section = notionContactSection(node.NodeTranslation, &node.ContactNode)
+		case 3:
+			if node.RatingNode.Label != "" {
+				section.blocks = append(section.blocks, notionTextBlock("paragraph", node.RatingNode.Label))
+				section.lines = append(section.lines, node.RatingNode.Label)
+			}
+			rows := [][]string{}
+			for _, element := range node.RatingNode.Elements {
+				rows = append(rows, []string{element.Label, fmt.Sprintf("%d/10", element.Value)})
+				section.lines = append(section.lines, fmt.Sprintf("%s: %d/10", element.Label, element.Value))
+			}
+			section.blocks = append(section.blocks, notionTable([]string{"Label", "Rating"}, rows))
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "notion")
+			continue
+		}
+		sections = append(sections, section)
+	}
+
+	size := len(children)
+	for _, section := range sections {
+		size += len(section.blocks)
+	}
+	// long forms get one block per node to stay within the block limit
+	compact := size > notionChildrenLimit
+	if compact && len(children)+len(sections) > notionChildrenLimit {
+		return nil, fmt.Errorf("notion page needs %d blocks, at most %d are allowed", len(children)+len(sections), notionChildrenLimit)
+	}
+	for _, section := range sections {
+		if !compact {
+			children = append(children, section.blocks...)
+		} else if len(section.lines) > 0 || section.title != "" {
+			children = append(children, section.compact())
+		}
+	}
+
+	return &Webhook{
+		Data: notionData{
+			Parent: notionParent{
+				DatabaseID: cfg.DatabaseID,
+			},
+			Properties: properties,
+			Children:   children,
+		},
+		Headers: map[string][]string{
+			"Authorization":  {"Bearer " + cfg.Token},
+			"Notion-Version": {"2022-06-28"},
+		},
+	}, nil
+}
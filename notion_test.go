@@ -0,0 +1,55 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNotionBlockLimit(t *testing.T) {
+	cfg := &DatabaseConfig{Token: "token", DatabaseID: "database"}
+	form := func(nodes int) *InputFormFinished {
+		data := &InputFormFinished{Title: "New submission", LinkText: "Open", LinkUrl: "https://example.com"}
+		for idx := 0; idx < nodes; idx++ {
+			data.Nodes = append(data.Nodes, InputFormFinishedNode{
+				Relation:        int64(idx),
+				NodeType:        1,
+				NodeTranslation: fmt.Sprintf("Question %d", idx),
+				SelectNode: InputSelectNode{
+					Selected: []string{fmt.Sprintf("answer %d", idx), strings.Repeat("x", 3000)},
+				},
+			})
+		}
+		return data
+	}
+
+	for _, nodes := range []int{10, 40, 99} {
+		webhook, err := notion(context.Background(), form(nodes), cfg)
+		if err != nil {
+			t.Fatalf("%d nodes: %v", nodes, err)
+		}
+		children := webhook.Data.(notionData).Children
+		if len(children) > notionChildrenLimit {
+			t.Errorf("%d nodes: got %d blocks", nodes, len(children))
+		}
+		body, err := json.Marshal(children)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(string(body), "x") < nodes*3000 {
+			t.Errorf("%d nodes: long answer was truncated", nodes)
+		}
+		for idx := 0; idx < nodes; idx++ {
+			if !strings.Contains(string(body), fmt.Sprintf(`"answer %d"`, idx)) && !strings.Contains(string(body), fmt.Sprintf(`answer %d\n`, idx)) {
+				t.Errorf("%d nodes: answer %d was dropped", nodes, idx)
+			}
+		}
+	}
+
+	// one block per node and the link still exceed the limit
+	if _, err := notion(context.Background(), form(100), cfg); err == nil {
+		t.Error("100 nodes mapped without error")
+	}
+}
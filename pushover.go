@@ -0,0 +1,123 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// limits documented at https://pushover.net/api#limits
+const (
+	pushoverTitleLimit    = 250
+	pushoverMessageLimit  = 1024
+	pushoverURLTitleLimit = 100
+	pushoverMinRetry      = 30 * time.Second
+	pushoverMaxExpire     = 3 * time.Hour
+)
+
+// PushoverConfig configures the Pushover adapter. Token is the application
+// API token, User the user or group key, Priority ranges from -2 to 2.
+// Emergency priority 2 repeats the notification every Retry until it is
+// acknowledged or Expire has passed, defaulting to 60s and 1h.
+type PushoverConfig struct {
+	Token    string
+	User     string
+	Priority int
+	Retry    time.Duration
+	Expire   time.Duration
+}
+
+// submissionContact returns the contact of the respondent, either the one
+// collected for the whole form or the first contact node.
+func submissionContact(data *InputFormFinished) *InputContactNode {
+	if data.Contact != nil {
+		return data.Contact
+	}
+	for idx := range data.Nodes {
+		if data.Nodes[idx].NodeType == 2 {
+			return &data.Nodes[idx].ContactNode
+		}
+	}
+	return nil
+}
+
+// submissionSummary describes data in a few short lines for push
+// notifications, which have no room for the answers themselves.
+func submissionSummary(data *InputFormFinished) []string {
+	lines := []string{}
+	if data.FormTranslation != "" {
+		lines = append(lines, data.FormTranslation)
+	}
+	if contact := submissionContact(data); contact != nil {
+		name := strings.TrimSpace(contact.Firstname + " " + contact.Lastname)
+		details := []string{}
+		for _, detail := range []string{name, contact.Email, contact.Company} {
+			if detail != "" {
+				details = append(details, detail)
+			}
+		}
+		if len(details) > 0 {
+			lines = append(lines, strings.Join(details, ", "))
+		}
+	}
+	if len(data.Nodes) == 1 {
+		lines = append(lines, "1 answer")
+	} else {
+		lines = append(lines, fmt.Sprintf("%d answers", len(data.Nodes)))
+	}
+	return lines
+}
+
+func pushover(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*PushoverConfig)
+	if !ok || cfg == nil || cfg.Token == "" || cfg.User == "" {
+		return nil, errors.New("pushover token or user not configured")
+	}
+	if cfg.Priority < -2 || cfg.Priority > 2 {
+		return nil, errors.New("pushover priority out of range")
+	}
+	lines := submissionSummary(data)
+	if len(lines) > 0 {
+		lines[0] = "<b>" + html.EscapeString(lines[0]) + "</b>"
+	}
+	for idx := 1; idx < len(lines); idx++ {
+		lines[idx] = html.EscapeString(lines[idx])
+	}
+
+	values := url.Values{
+		"token":    {cfg.Token},
+		"user":     {cfg.User},
+		"title":    {truncateRunes(data.Title, pushoverTitleLimit)},
+		"message":  {truncateRunes(strings.Join(lines, "\n"), pushoverMessageLimit)},
+		"html":     {"1"},
+		"priority": {strconv.Itoa(cfg.Priority)},
+	}
+	if cfg.Priority == 2 {
+		retry, expire := cfg.Retry, cfg.Expire
+		if retry == 0 {
+			retry = time.Minute
+		}
+		if expire == 0 {
+			expire = time.Hour
+		}
+		if retry < pushoverMinRetry || expire > pushoverMaxExpire {
+			return nil, errors.New("pushover retry below 30s or expire above 3h")
+		}
+		values.Set("retry", strconv.Itoa(int(retry.Seconds())))
+		values.Set("expire", strconv.Itoa(int(expire.Seconds())))
+	}
+	if data.LinkUrl != "" {
+		values.Set("url", data.LinkUrl)
+		values.Set("url_title", truncateRunes(data.LinkText, pushoverURLTitleLimit))
+	}
+
+	return &Webhook{
+		Data:    values,
+		Headers: nil,
+	}, nil
+}
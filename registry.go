@@ -0,0 +1,103 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Adapter maps the input of an event to the webhook of a target platform.
+// config is the integration specific configuration (e.g. *ZulipConfig) and
+// may be nil for adapters that do not need any. ctx carries deadlines and
+// the logger set with WithLogger.
+type Adapter func(ctx context.Context, input interface{}, eventType EventType, config interface{}) (*Webhook, error)
+
+// ErrAdapterRegistered is returned by Register for a type that is taken.
+var ErrAdapterRegistered = errors.New("adapter already registered")
+
+type registration struct {
+	adapter Adapter
+	detail  AdapterDetail
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[IntegrationType]registration{}
+)
+
+func init() {
+	for adapterType, adapter := range builtinAdapters {
+		if err := Register(adapterType, adapter, builtinAdapterDetails[adapterType]); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds an adapter under adapterType, which allows modules to ship
+// their own adapters next to the built-in ones. Types that are already
+// registered are rejected with ErrAdapterRegistered.
+func Register(adapterType IntegrationType, adapter Adapter, detail AdapterDetail) error {
+	if adapter == nil {
+		return errors.New("adapter undefined")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[adapterType]; ok {
+		return fmt.Errorf("%w, type: %d", ErrAdapterRegistered, adapterType)
+	}
+	registry[adapterType] = registration{
+		adapter: adapter,
+		detail:  detail,
+	}
+	return nil
+}
+
+// Unregister removes the adapter of adapterType. It is meant for tests that
+// register adapters of their own.
+func Unregister(adapterType IntegrationType) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	delete(registry, adapterType)
+}
+
+// Lookup returns the adapter and details registered under adapterType.
+func Lookup(adapterType IntegrationType) (Adapter, AdapterDetail, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	entry, ok := registry[adapterType]
+	return entry.adapter, entry.detail, ok
+}
+
+// Registered returns all registered types in ascending order.
+func Registered() []IntegrationType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	types := make([]IntegrationType, 0, len(registry))
+	for adapterType := range registry {
+		types = append(types, adapterType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// MinTypeID returns the lowest registered type, or -1 if none is registered.
+func MinTypeID() int64 {
+	types := Registered()
+	if len(types) == 0 {
+		return -1
+	}
+	return int64(types[0])
+}
+
+// MaxTypeID returns the highest registered type, or -1 if none is registered.
+func MaxTypeID() int64 {
+	types := Registered()
+	if len(types) == 0 {
+		return -1
+	}
+	return int64(types[len(types)-1])
+}
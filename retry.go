@@ -0,0 +1,194 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a Deliverer retries rate limited and failed
+// deliveries. A 429 means the platform did not accept the message, so it is
+// always retried. Network errors and 5xx responses other than 501 leave
+// open whether the message was processed and are only retried for PUT
+// requests, or for all requests with RetryServerErrors. Other 4xx like
+// invalid_payload or channel_not_found are never retried.
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt, defaults to 3.
+	MaxAttempts int
+	// InitialBackoff is doubled after each attempt up to MaxBackoff,
+	// defaults to 500ms and 30s. A Retry-After sent by the platform takes
+	// precedence, but delivery gives up if it exceeds MaxBackoff or the
+	// deadline of the context.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryServerErrors retries POST requests after network errors and 5xx
+	// responses as well, at the risk of duplicate messages.
+	RetryServerErrors bool
+	// OnAttempt is called after each attempt, e.g. for logging and metrics.
+	OnAttempt func(ctx context.Context, attempt DeliveryAttempt)
+}
+
+// DeliveryAttempt describes a single attempt to deliver a webhook. Result
+// is nil if no response was received.
+type DeliveryAttempt struct {
+	Attempt int
+	Result  *DeliveryResult
+	Err     error
+	// Wait is the delay before the next attempt, zero if there is none.
+	Wait time.Duration
+}
+
+func (policy *RetryPolicy) maxAttempts() int {
+	if policy == nil {
+		return 1
+	}
+	if policy.MaxAttempts <= 0 {
+		return 3
+	}
+	return policy.MaxAttempts
+}
+
+func (policy *RetryPolicy) maxBackoff() time.Duration {
+	if policy.MaxBackoff <= 0 {
+		return 30 * time.Second
+	}
+	return policy.MaxBackoff
+}
+
+func (policy *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max := policy.InitialBackoff, policy.maxBackoff()
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+
+	backoff := initial
+	for i := 1; i < attempt && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+
+	// Equal jitter keeps at least half of the backoff.
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// retryable reports whether the attempt to deliver webhook may be repeated
+// under policy.
+func (policy *RetryPolicy) retryable(ctx context.Context, webhook *Webhook, result *DeliveryResult, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	// the platform may have processed the message already
+	uncertain := policy.RetryServerErrors || webhook.Method == http.MethodPut
+
+	if result == nil {
+		// encoding and url errors fail the same way on every attempt
+		var transport *transportError
+		return uncertain && errors.As(err, &transport)
+	}
+
+	switch {
+	case result.StatusCode == http.StatusTooManyRequests:
+		return true
+	case result.StatusCode == http.StatusNotImplemented:
+		return false
+	case result.StatusCode >= 500:
+		return uncertain
+	}
+	return false
+}
+
+// retryAfter returns the delay requested by the platform in the Retry-After
+// header, either in seconds or as a date, or in the retry_after field of the
+// body as sent by Discord, and whether the platform requested one. ok is
+// false if the delay exceeds limit, including values too large for a
+// Duration.
+func retryAfter(result *DeliveryResult, limit time.Duration) (wait time.Duration, found bool, ok bool) {
+	if result == nil {
+		return 0, false, false
+	}
+
+	if value := result.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+			wait, ok := retryAfterSeconds(seconds, limit)
+			return wait, true, ok
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			wait := max(time.Until(date), 0)
+			return wait, true, wait <= limit
+		}
+	}
+
+	response := struct {
+		RetryAfter *float64 `json:"retry_after"`
+	}{}
+	if json.Unmarshal(result.Body, &response) == nil && response.RetryAfter != nil && *response.RetryAfter >= 0 {
+		wait, ok := retryAfterSeconds(*response.RetryAfter, limit)
+		return wait, true, ok
+	}
+
+	return 0, false, false
+}
+
+// retryAfterSeconds converts seconds to a Duration if they are within limit,
+// checked before the conversion so that huge values cannot overflow.
+func retryAfterSeconds(seconds float64, limit time.Duration) (time.Duration, bool) {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) || seconds > limit.Seconds() {
+		return 0, false
+	}
+	return time.Duration(seconds * float64(time.Second)), true
+}
+
+// deliverWithRetry delivers a single webhook according to the Retry policy
+// of the Deliverer.
+func (d *Deliverer) deliverWithRetry(ctx context.Context, rawURL string, webhook *Webhook) (*DeliveryResult, error) {
+	maxAttempts := d.Retry.maxAttempts()
+
+	for attempt := 1; ; attempt++ {
+		result, err := d.deliver(ctx, rawURL, webhook)
+		if result != nil {
+			result.Attempts = attempt
+		}
+
+		wait := time.Duration(0)
+		retry := attempt < maxAttempts && d.Retry.retryable(ctx, webhook, result, err)
+		if retry {
+			if after, found, ok := retryAfter(result, d.Retry.maxBackoff()); found {
+				wait = after
+				// waiting longer than the policy allows would stall the
+				// delivery of everything queued behind this webhook
+				retry = ok
+			} else {
+				wait = d.Retry.backoff(attempt)
+			}
+		}
+		if deadline, ok := ctx.Deadline(); retry && ok && time.Until(deadline) < wait {
+			retry = false
+		}
+		if !retry {
+			wait = 0
+		}
+
+		if d.Retry != nil && d.Retry.OnAttempt != nil {
+			d.Retry.OnAttempt(ctx, DeliveryAttempt{Attempt: attempt, Result: result, Err: err, Wait: wait})
+		}
+
+		if !retry {
+			return result, err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
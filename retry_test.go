@@ -0,0 +1,234 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDeliverRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		method   string
+		statuses []int
+		header   map[string]string
+		body     string
+		attempts int
+		success  bool
+	}{
+		{
+			name:     "rate limited",
+			statuses: []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			header:   map[string]string{"Retry-After": "0"},
+			attempts: 3,
+			success:  true,
+		},
+		{
+			name:     "discord retry_after",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			body:     `{"message":"You are being rate limited.","retry_after":0.001,"global":false}`,
+			attempts: 2,
+			success:  true,
+		},
+		{
+			name:     "retry after above max backoff",
+			policy:   RetryPolicy{MaxBackoff: time.Second},
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   map[string]string{"Retry-After": "3600"},
+			attempts: 1,
+		},
+		{
+			name:     "retry after overflow",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   map[string]string{"Retry-After": "1e20"},
+			attempts: 1,
+		},
+		{
+			name:     "retry after infinite",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			header:   map[string]string{"Retry-After": "Inf"},
+			attempts: 1,
+		},
+		{
+			name:     "discord retry_after overflow",
+			statuses: []int{http.StatusTooManyRequests, http.StatusOK},
+			body:     `{"message":"You are being rate limited.","retry_after":1e300,"global":false}`,
+			attempts: 1,
+		},
+		{
+			name:     "max attempts",
+			statuses: []int{http.StatusTooManyRequests},
+			attempts: 3,
+		},
+		{
+			name:     "invalid payload",
+			statuses: []int{http.StatusBadRequest, http.StatusOK},
+			body:     "invalid_payload",
+			attempts: 1,
+		},
+		{
+			name:     "server error",
+			statuses: []int{http.StatusBadGateway, http.StatusOK},
+			attempts: 1,
+		},
+		{
+			name:     "server error opted in",
+			policy:   RetryPolicy{RetryServerErrors: true},
+			statuses: []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			attempts: 3,
+			success:  true,
+		},
+		{
+			name:     "server error put",
+			method:   http.MethodPut,
+			statuses: []int{http.StatusInternalServerError, http.StatusOK},
+			attempts: 2,
+			success:  true,
+		},
+		{
+			name:     "not implemented",
+			policy:   RetryPolicy{RetryServerErrors: true},
+			statuses: []int{http.StatusNotImplemented, http.StatusOK},
+			attempts: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := atomic.Int32{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				idx := int(requests.Add(1)) - 1
+				status := test.statuses[min(idx, len(test.statuses)-1)]
+				if status != http.StatusOK {
+					for key, value := range test.header {
+						w.Header().Set(key, value)
+					}
+				}
+				w.WriteHeader(status)
+				if status != http.StatusOK {
+					w.Write([]byte(test.body))
+				}
+			}))
+			defer server.Close()
+
+			attempts := []DeliveryAttempt{}
+			policy := test.policy
+			policy.InitialBackoff = time.Millisecond
+			policy.OnAttempt = func(_ context.Context, attempt DeliveryAttempt) {
+				attempts = append(attempts, attempt)
+			}
+			deliverer := &Deliverer{Retry: &policy}
+
+			result, err := deliverer.Deliver(context.Background(), server.URL, &Webhook{Method: test.method, Data: "message"})
+			if test.success != (err == nil) {
+				t.Fatalf("err = %v, want success %t", err, test.success)
+			}
+			if int(requests.Load()) != test.attempts || len(attempts) != test.attempts {
+				t.Fatalf("got %d requests and %d attempts, want %d", requests.Load(), len(attempts), test.attempts)
+			}
+			if result.Attempts != test.attempts {
+				t.Errorf("result.Attempts = %d, want %d", result.Attempts, test.attempts)
+			}
+			for idx, attempt := range attempts {
+				if attempt.Attempt != idx+1 {
+					t.Errorf("attempt %d reported as %d", idx+1, attempt.Attempt)
+				}
+				last := idx == len(attempts)-1
+				if last && attempt.Wait != 0 {
+					t.Errorf("last attempt waits %s", attempt.Wait)
+				}
+				if attempt.Wait > time.Second {
+					t.Errorf("attempt %d waits %s", idx+1, attempt.Wait)
+				}
+			}
+		})
+	}
+}
+
+func TestDeliverRetryFinalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		webhook *Webhook
+	}{
+		{"email", "http://127.0.0.1:1", &Webhook{Data: EmailMessage{}}},
+		{"json", "http://127.0.0.1:1", &Webhook{Data: func() {}}},
+		{"url", "http://[::1", &Webhook{Data: "message"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attempts := 0
+			deliverer := &Deliverer{Retry: &RetryPolicy{
+				RetryServerErrors: true,
+				InitialBackoff:    time.Millisecond,
+				OnAttempt: func(context.Context, DeliveryAttempt) {
+					attempts++
+				},
+			}}
+			if _, err := deliverer.Deliver(context.Background(), test.url, test.webhook); err == nil {
+				t.Fatal("delivered without error")
+			}
+			if attempts != 1 {
+				t.Errorf("got %d attempts, want 1", attempts)
+			}
+		})
+	}
+}
+
+func TestDeliverRetryNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	for _, test := range []struct {
+		policy   RetryPolicy
+		attempts int
+	}{
+		{RetryPolicy{}, 1},
+		{RetryPolicy{RetryServerErrors: true}, 3},
+	} {
+		attempts := 0
+		policy := test.policy
+		policy.InitialBackoff = time.Millisecond
+		policy.OnAttempt = func(_ context.Context, attempt DeliveryAttempt) {
+			attempts++
+			if attempt.Result != nil {
+				t.Errorf("network error reported with a result")
+			}
+		}
+		_, err := (&Deliverer{Retry: &policy}).Deliver(context.Background(), url, &Webhook{Data: "message"})
+		if err == nil || errors.Is(err, ErrDeliveryStatus) {
+			t.Errorf("err = %v, want a network error", err)
+		}
+		if attempts != test.attempts {
+			t.Errorf("RetryServerErrors %t: got %d attempts, want %d", policy.RetryServerErrors, attempts, test.attempts)
+		}
+	}
+}
+
+func TestDeliverRetryDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	result, err := (&Deliverer{Retry: &RetryPolicy{}}).Deliver(ctx, server.URL, &Webhook{Data: "message"})
+	if !errors.Is(err, ErrDeliveryStatus) {
+		t.Fatalf("err = %v, want ErrDeliveryStatus", err)
+	}
+	if result.Attempts != 1 {
+		t.Errorf("got %d attempts, want 1", result.Attempts)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("waited %s for a retry past the deadline", elapsed)
+	}
+}
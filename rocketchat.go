@@ -0,0 +1,32 @@
+package integrations
+
+import "context"
+
+type rocketChatData struct {
+	Text        string                     `json:"text"`
+	Attachments []rocketChatDataAttachment `json:"attachments"`
+}
+
+type rocketChatDataAttachment struct {
+	Title     string `json:"title,omitempty"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text"`
+	Color     string `json:"color,omitempty"`
+}
+
+func rocketChat(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	return &Webhook{
+		Data: rocketChatData{
+			Text: data.Title,
+			Attachments: []rocketChatDataAttachment{
+				{
+					Title:     data.LinkText,
+					TitleLink: data.LinkUrl,
+					Color:     "#1B5495",
+					Text:      markdownMessage(ctx, data, "rocketchat"),
+				},
+			},
+		},
+		Headers: nil,
+	}, nil
+}
@@ -0,0 +1,164 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SheetsContactRelation is the Relation of the columns holding the contact
+// collected for the whole form, which has no node of its own.
+const SheetsContactRelation int64 = -1
+
+// SheetsColumn is a column of the Google Sheets adapter. Key is the element
+// label for choice and rating nodes, the json name of the field for contacts
+// and empty for select nodes.
+type SheetsColumn struct {
+	Relation int64
+	Key      string
+	Header   string
+}
+
+// SheetsConfig configures the Google Sheets adapter. Layout fixes the
+// columns of the row, so rows of the same form line up even if respondents
+// skip questions; it should be created once per form with SheetsLayout and
+// stored. Without it the layout is derived from each submission. Range is
+// the A1 notation of the table, e.g. "Sheet1".
+type SheetsConfig struct {
+	Token  string
+	Range  string
+	Layout []SheetsColumn
+}
+
+type sheetsData struct {
+	Range          string          `json:"range"`
+	MajorDimension string          `json:"majorDimension"`
+	Values         [][]interface{} `json:"values"`
+}
+
+type sheetsKey struct {
+	relation int64
+	key      string
+}
+
+func sheetsContactCells(cells map[sheetsKey]interface{}, relation int64, contact *InputContactNode) {
+	for _, field := range contactFields(contact) {
+		cells[sheetsKey{relation, field.Name}] = field.Value
+	}
+}
+
+// sheetsCells flattens data into cells keyed by node Relation and column
+// key, along with the columns in the order they appear in data.
+func sheetsCells(ctx context.Context, data *InputFormFinished) (map[sheetsKey]interface{}, []SheetsColumn) {
+	cells := map[sheetsKey]interface{}{}
+	columns := []SheetsColumn{}
+
+	if data.Contact != nil {
+		sheetsContactCells(cells, SheetsContactRelation, data.Contact)
+		for _, field := range contactFields(data.Contact) {
+			columns = append(columns, SheetsColumn{Relation: SheetsContactRelation, Key: field.Name, Header: field.Label})
+		}
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				answers := []string{}
+				for _, answer := range []string{element.AnswerShort, element.AnswerLong} {
+					if answer != "" {
+						answers = append(answers, answer)
+					}
+				}
+				value := strings.Join(answers, "\n")
+				if value == "" {
+					value = element.Label
+				}
+				cells[sheetsKey{node.Relation, element.Label}] = value
+				columns = append(columns, SheetsColumn{Relation: node.Relation, Key: element.Label, Header: node.NodeTranslation + ": " + element.Label})
+			}
+		case 1:
+			cells[sheetsKey{node.Relation, ""}] = strings.Join(node.SelectNode.Selected, ", ")
+			columns = append(columns, SheetsColumn{Relation: node.Relation, Header: node.NodeTranslation})
+		case 2:
+			sheetsContactCells(cells, node.Relation, &node.ContactNode)
+			for _, field := range contactFields(&node.ContactNode) {
+				columns = append(columns, SheetsColumn{Relation: node.Relation, Key: field.Name, Header: node.NodeTranslation + ": " + field.Label})
+			}
+		case 3:
+			for _, element := range node.RatingNode.Elements {
+				cells[sheetsKey{node.Relation, element.Label}] = element.Value
+				columns = append(columns, SheetsColumn{Relation: node.Relation, Key: element.Label, Header: node.NodeTranslation + ": " + element.Label})
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "sheets")
+			continue
+		}
+	}
+
+	return cells, columns
+}
+
+// SheetsLayout derives the columns of the Google Sheets adapter from data,
+// ordered by node Relation. It is meant to be called with a submission that
+// answers every question (or a form preview) and the result kept in
+// SheetsConfig.Layout, the headers make up the first row of the sheet.
+func SheetsLayout(data *InputFormFinished) []SheetsColumn {
+	return sheetsLayout(context.Background(), data)
+}
+
+func sheetsLayout(ctx context.Context, data *InputFormFinished) []SheetsColumn {
+	_, columns := sheetsCells(ctx, data)
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].Relation < columns[j].Relation
+	})
+	return columns
+}
+
+func sheets(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*SheetsConfig)
+	if !ok || cfg == nil || cfg.Range == "" {
+		return nil, errors.New("sheets range not configured")
+	}
+	layout := cfg.Layout
+	if len(layout) == 0 {
+		loggerFrom(ctx).WarnContext(ctx, "sheets layout not configured, columns may shift between submissions", "adapter", "sheets")
+		layout = sheetsLayout(ctx, data)
+	}
+
+	cells, _ := sheetsCells(ctx, data)
+	row := make([]interface{}, len(layout))
+	for idx, column := range layout {
+		if value, ok := cells[sheetsKey{column.Relation, column.Key}]; ok {
+			row[idx] = value
+		} else {
+			row[idx] = ""
+		}
+	}
+
+	var headers map[string][]string
+	if cfg.Token != "" {
+		headers = map[string][]string{
+			"Authorization": {"Bearer " + cfg.Token},
+		}
+	}
+
+	return &Webhook{
+		Data: sheetsData{
+			Range:          cfg.Range,
+			MajorDimension: "ROWS",
+			Values:         [][]interface{}{row},
+		},
+		Headers: headers,
+		// RAW keeps answers like "=1+1" or "+49 …" from being parsed
+		Query: url.Values{
+			"valueInputOption": {"RAW"},
+			"insertDataOption": {"INSERT_ROWS"},
+		},
+	}, nil
+}
@@ -0,0 +1,125 @@
+package integrations
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// SMTPSender delivers the EmailMessage produced by the email adapter. The
+// connection is upgraded with STARTTLS and authenticated with AUTH PLAIN when
+// a username is set.
+type SMTPSender struct {
+	// Addr is the host:port of the SMTP server, usually port 587.
+	Addr     string
+	Username string
+	Password string
+	// TLSConfig defaults to verifying the certificate against the host of
+	// Addr, which is also used when its ServerName is empty.
+	TLSConfig *tls.Config
+	// AllowInsecure permits servers that do not offer STARTTLS, which is only
+	// meant for local test servers.
+	AllowInsecure bool
+	// Timeout bounds the whole exchange, defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+func (s *SMTPSender) Send(webhook *Webhook) error {
+	return s.SendContext(context.Background(), webhook)
+}
+
+// SendContext is Send bounded by ctx, the connection is closed as soon as
+// ctx is done.
+func (s *SMTPSender) SendContext(ctx context.Context, webhook *Webhook) error {
+	if webhook == nil {
+		return errors.New("webhook undefined")
+	}
+	message, ok := webhook.Data.(EmailMessage)
+	if !ok {
+		return errors.New("type assertion failed for EmailMessage")
+	}
+
+	host, _, err := net.SplitHostPort(s.Addr)
+	if err != nil {
+		return err
+	}
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	// unblocks the exchange when ctx is canceled before the deadline
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		tlsConfig := &tls.Config{}
+		if s.TLSConfig != nil {
+			tlsConfig = s.TLSConfig.Clone()
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = host
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	} else if !s.AllowInsecure {
+		return errors.New("smtp server does not support STARTTLS")
+	}
+
+	if s.Username != "" {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp server does not support AUTH")
+		}
+		// smtp.PlainAuth refuses to send credentials over unencrypted
+		// connections to anything but localhost
+		if err := client.Auth(smtp.PlainAuth("", s.Username, s.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(message.From); err != nil {
+		return err
+	}
+	for _, recipient := range message.To {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message.Raw); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,320 @@
+package integrations
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpEnvelope is what the test server received in a single connection.
+type smtpEnvelope struct {
+	from string
+	to   []string
+	data []byte
+	// tls reports whether MAIL FROM was sent over the upgraded connection.
+	tls      bool
+	username string
+	password string
+}
+
+// serveSMTP runs a minimal SMTP server on 127.0.0.1 that accepts a single
+// connection and reports the received envelope once it is closed. With
+// tlsConfig set the server offers STARTTLS and, once upgraded, AUTH PLAIN.
+func serveSMTP(t *testing.T, tlsConfig *tls.Config) (string, <-chan smtpEnvelope) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan smtpEnvelope, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		envelope := smtpEnvelope{}
+		defer func() {
+			conn.Close()
+			received <- envelope
+		}()
+		text := textproto.NewConn(conn)
+		upgraded := false
+
+		text.PrintfLine("220 localhost ESMTP test")
+		for {
+			line, err := text.ReadLine()
+			if err != nil {
+				return
+			}
+			command := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				switch {
+				case tlsConfig != nil && !upgraded:
+					text.PrintfLine("250-localhost")
+					text.PrintfLine("250 STARTTLS")
+				case tlsConfig != nil:
+					text.PrintfLine("250-localhost")
+					text.PrintfLine("250 AUTH PLAIN")
+				default:
+					text.PrintfLine("250 localhost")
+				}
+			case command == "STARTTLS" && tlsConfig != nil && !upgraded:
+				text.PrintfLine("220 Ready to start TLS")
+				tlsConn := tls.Server(conn, tlsConfig)
+				if err := tlsConn.Handshake(); err != nil {
+					return
+				}
+				conn = tlsConn
+				text = textproto.NewConn(conn)
+				upgraded = true
+			case strings.HasPrefix(command, "AUTH PLAIN ") && upgraded:
+				credentials, err := base64.StdEncoding.DecodeString(line[len("AUTH PLAIN "):])
+				parts := strings.Split(string(credentials), "\x00")
+				if err != nil || len(parts) != 3 {
+					text.PrintfLine("501 Malformed credentials")
+					continue
+				}
+				envelope.username, envelope.password = parts[1], parts[2]
+				text.PrintfLine("235 Authentication successful")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				envelope.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				envelope.tls = upgraded
+				text.PrintfLine("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				envelope.to = append(envelope.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+				text.PrintfLine("250 OK")
+			case command == "DATA":
+				text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+				// the dot reader undoes dot-stuffing but turns CRLF into LF
+				data, err := io.ReadAll(text.DotReader())
+				if err != nil {
+					return
+				}
+				envelope.data = data
+				text.PrintfLine("250 OK")
+			case command == "QUIT":
+				text.PrintfLine("221 Bye")
+				return
+			default:
+				text.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), received
+}
+
+// smtpTLS returns the self-signed certificate of an httptest server for
+// 127.0.0.1, as server config and as client config trusting it.
+func smtpTLS(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(nil)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	return &tls.Config{Certificates: server.TLS.Certificates}, &tls.Config{RootCAs: roots}
+}
+
+func receiveSMTP(t *testing.T, received <-chan smtpEnvelope) smtpEnvelope {
+	t.Helper()
+	select {
+	case envelope := <-received:
+		return envelope
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp server did not finish")
+	}
+	return smtpEnvelope{}
+}
+
+func testEmail(t *testing.T) *Webhook {
+	t.Helper()
+	webhook, err := email(context.Background(), &InputFormFinished{Title: "New submission"}, &EmailConfig{
+		From: "forms@example.com",
+		To:   []string{"team@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return webhook
+}
+
+func TestSMTPSenderSTARTTLS(t *testing.T) {
+	serverTLS, clientTLS := smtpTLS(t)
+	addr, received := serveSMTP(t, serverTLS)
+
+	sender := &SMTPSender{
+		Addr:      addr,
+		Username:  "forms",
+		Password:  "secret",
+		TLSConfig: clientTLS,
+		Timeout:   5 * time.Second,
+	}
+	if err := sender.Send(testEmail(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := receiveSMTP(t, received)
+	if !envelope.tls {
+		t.Error("message sent before the connection was upgraded")
+	}
+	if envelope.username != "forms" || envelope.password != "secret" {
+		t.Errorf("credentials = %q/%q, want forms/secret", envelope.username, envelope.password)
+	}
+	if envelope.from != "forms@example.com" || len(envelope.data) == 0 {
+		t.Errorf("envelope = %+v, want a delivered message", envelope)
+	}
+}
+
+func TestSMTPSenderRequiresSTARTTLS(t *testing.T) {
+	addr, received := serveSMTP(t, nil)
+
+	sender := &SMTPSender{Addr: addr, Username: "forms", Password: "secret", Timeout: 5 * time.Second}
+	err := sender.Send(testEmail(t))
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("err = %v, want STARTTLS to be required", err)
+	}
+
+	envelope := receiveSMTP(t, received)
+	if envelope.from != "" || envelope.username != "" {
+		t.Errorf("server without STARTTLS received %+v", envelope)
+	}
+}
+
+func TestSMTPSenderUntrustedCertificate(t *testing.T) {
+	serverTLS, _ := smtpTLS(t)
+	addr, received := serveSMTP(t, serverTLS)
+
+	sender := &SMTPSender{Addr: addr, Username: "forms", Password: "secret", Timeout: 5 * time.Second}
+	if err := sender.Send(testEmail(t)); err == nil {
+		t.Fatal("sent over a connection with an untrusted certificate")
+	}
+
+	envelope := receiveSMTP(t, received)
+	if envelope.username != "" {
+		t.Errorf("credentials sent to an untrusted server: %+v", envelope)
+	}
+}
+
+func TestSMTPSender(t *testing.T) {
+	addr, received := serveSMTP(t, nil)
+
+	data := &InputFormFinished{
+		Title:           "Neue Einreichung – Übersicht\r\nBcc: attacker@example.com",
+		FormTranslation: "Feedback",
+		LinkText:        "Open",
+		LinkUrl:         "https://example.com/submission",
+		Contact: &InputContactNode{
+			Firstname: "Jane",
+			Email:     "jane@example.com",
+		},
+		Nodes: []InputFormFinishedNode{
+			{
+				NodeType:        1,
+				NodeTranslation: "Options",
+				SelectNode:      InputSelectNode{Selected: []string{".leading dot", "<b>not html</b>"}},
+			},
+		},
+	}
+	webhook, err := email(context.Background(), data, &EmailConfig{
+		From: "Forms <forms@example.com>",
+		To:   []string{"team@example.com", "Ops <ops@example.com>"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &SMTPSender{Addr: addr, AllowInsecure: true, Timeout: 5 * time.Second}
+	if err := sender.Send(webhook); err != nil {
+		t.Fatal(err)
+	}
+
+	envelope := receiveSMTP(t, received)
+
+	if envelope.from != "forms@example.com" {
+		t.Errorf("MAIL FROM = %q", envelope.from)
+	}
+	if strings.Join(envelope.to, ",") != "team@example.com,ops@example.com" {
+		t.Errorf("RCPT TO = %q", envelope.to)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(envelope.data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(message.Header["Bcc"]) > 0 {
+		t.Errorf("title injected a Bcc header: %q", message.Header["Bcc"])
+	}
+	rawSubject := message.Header.Get("Subject")
+	if !strings.HasPrefix(rawSubject, "=?utf-8?q?") {
+		t.Errorf("subject %q is not Q-encoded", rawSubject)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(rawSubject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strings.TrimSpace(data.Title); subject != want {
+		t.Errorf("subject = %q, want %q", subject, want)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q", mediaType)
+	}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	parts := map[string]string{}
+	order := []string{}
+	for {
+		// the raw part keeps the Content-Transfer-Encoding header
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if encoding := part.Header.Get("Content-Transfer-Encoding"); encoding != "quoted-printable" {
+			t.Errorf("%s part encoded as %q", partType, encoding)
+		}
+		body, err := io.ReadAll(quotedprintable.NewReader(part))
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[partType] = string(body)
+		order = append(order, partType)
+	}
+
+	if strings.Join(order, ",") != "text/plain,text/html" {
+		t.Fatalf("parts = %q, want text/plain then text/html", order)
+	}
+	for _, want := range []string{"Jane", "jane@example.com", ".leading dot", "<b>not html</b>", data.LinkUrl} {
+		if !strings.Contains(parts["text/plain"], want) {
+			t.Errorf("plain part misses %q:\n%s", want, parts["text/plain"])
+		}
+	}
+	for _, want := range []string{"Jane", ".leading dot", "&lt;b&gt;not html&lt;/b&gt;", `href="https://example.com/submission"`} {
+		if !strings.Contains(parts["text/html"], want) {
+			t.Errorf("html part misses %q:\n%s", want, parts["text/html"])
+		}
+	}
+}
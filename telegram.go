@@ -0,0 +1,252 @@
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const telegramMessageLimit = 4096
+
+// TelegramConfig routes messages of the Telegram adapter. ChatID is either the
+// numeric chat id or the @username of a public channel, MessageThreadID
+// optionally targets a topic of a forum supergroup.
+type TelegramConfig struct {
+	ChatID          string
+	MessageThreadID int64
+}
+
+type telegramData struct {
+	ChatID          string               `json:"chat_id"`
+	MessageThreadID int64                `json:"message_thread_id,omitempty"`
+	Text            string               `json:"text"`
+	ParseMode       string               `json:"parse_mode"`
+	ReplyMarkup     *telegramReplyMarkup `json:"reply_markup,omitempty"`
+}
+
+type telegramReplyMarkup struct {
+	InlineKeyboard [][]telegramInlineButton `json:"inline_keyboard"`
+}
+
+type telegramInlineButton struct {
+	Text string `json:"text"`
+	URL  string `json:"url"`
+}
+
+// telegramEscaper escapes every character MarkdownV2 treats as markup, see
+// https://core.telegram.org/bots/api#markdownv2-style
+var telegramEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"_", "\\_",
+	"*", "\\*",
+	"[", "\\[",
+	"]", "\\]",
+	"(", "\\(",
+	")", "\\)",
+	"~", "\\~",
+	"`", "\\`",
+	">", "\\>",
+	"#", "\\#",
+	"+", "\\+",
+	"-", "\\-",
+	"=", "\\=",
+	"|", "\\|",
+	"{", "\\{",
+	"}", "\\}",
+	".", "\\.",
+	"!", "\\!",
+)
+
+// telegramLine is a single escaped line of a message, lines are never split
+// across messages unless they exceed the message limit on their own.
+type telegramLine struct {
+	text string
+	bold bool
+}
+
+func (line telegramLine) render() string {
+	if line.bold && line.text != "" {
+		return "*" + line.text + "*"
+	}
+	return line.text
+}
+
+// telegramSplit cuts escaped text into pieces of at most limit runes without
+// separating an escape backslash from the character it escapes.
+func telegramSplit(text string, limit int) []string {
+	pieces := []string{}
+	runes := []rune(text)
+	for len(runes) > limit {
+		cut := 0
+		for cut < limit {
+			step := 1
+			if runes[cut] == '\\' {
+				step = 2
+			}
+			if cut+step > limit {
+				break
+			}
+			cut += step
+		}
+		pieces = append(pieces, string(runes[:cut]))
+		runes = runes[cut:]
+	}
+	return append(pieces, string(runes))
+}
+
+func telegramChunks(lines []telegramLine, limit int) []string {
+	chunks := []string{}
+	current := &strings.Builder{}
+	currentLen := 0
+
+	add := func(rendered string) {
+		size := utf8.RuneCountInString(rendered)
+		if currentLen > 0 && currentLen+1+size > limit {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+		if currentLen > 0 {
+			current.WriteString("\n")
+			currentLen++
+		}
+		current.WriteString(rendered)
+		currentLen += size
+	}
+
+	for _, line := range lines {
+		rendered := line.render()
+		if utf8.RuneCountInString(rendered) <= limit {
+			add(rendered)
+			continue
+		}
+		for _, piece := range telegramSplit(line.text, limit-2) {
+			add(telegramLine{text: piece, bold: line.bold}.render())
+		}
+	}
+	if currentLen > 0 || len(chunks) == 0 {
+		chunks = append(chunks, current.String())
+	}
+
+	return chunks
+}
+
+func telegramContactLines(contact *InputContactNode) []telegramLine {
+	lines := []telegramLine{}
+	for _, field := range filledContactFields(contact) {
+		lines = append(lines, telegramLine{
+			text: telegramEscaper.Replace(fmt.Sprintf("%s: %s", field.Label, field.Value)),
+		})
+	}
+	return lines
+}
+
+func telegram(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*TelegramConfig)
+	if !ok || cfg == nil || cfg.ChatID == "" {
+		return nil, errors.New("telegram chat id not configured")
+	}
+	lines := []telegramLine{}
+	if title := strings.TrimSpace(data.Title); title != "" {
+		lines = append(lines, telegramLine{text: telegramEscaper.Replace(title), bold: true})
+	}
+	if data.FormTranslation != "" {
+		lines = append(lines, telegramLine{text: telegramEscaper.Replace(data.FormTranslation)})
+	}
+
+	if data.Contact != nil {
+		lines = append(lines, telegramLine{})
+		lines = append(lines, telegramContactLines(data.Contact)...)
+	}
+
+	for _, node := range data.Nodes {
+		if node.NodeTranslation == "" {
+			node.NodeTranslation = "Missing Translation"
+		}
+		nodeLines := []telegramLine{
+			{},
+			{text: telegramEscaper.Replace(node.NodeTranslation), bold: true},
+		}
+		switch node.NodeType {
+		case 0:
+			for _, element := range node.ChoiceNode.Elements {
+				if element.Label == "" {
+					continue
+				}
+				nodeLines = append(nodeLines, telegramLine{text: telegramEscaper.Replace("• " + element.Label)})
+				if element.AnswerShort != "" {
+					nodeLines = append(nodeLines, telegramLine{text: telegramEscaper.Replace(element.AnswerShort)})
+				}
+				if element.AnswerLong != "" {
+					nodeLines = append(nodeLines, telegramLine{text: telegramEscaper.Replace(element.AnswerLong)})
+				}
+			}
+		case 1:
+			if node.SelectNode.Label != "" {
+				nodeLines = append(nodeLines, telegramLine{text: telegramEscaper.Replace(node.SelectNode.Label)})
+			}
+			for _, selected := range node.SelectNode.Selected {
+				nodeLines = append(nodeLines, telegramLine{text: telegramEscaper.Replace("• " + selected)})
+			}
+		case 2:
+			nodeLines = append(nodeLines, telegramContactLines(&node.ContactNode)...)
+		case 3:
+			if node.RatingNode.Label != "" {
+				nodeLines = append(nodeLines, telegramLine{text: telegramEscaper.Replace(node.RatingNode.Label)})
+			}
+			for _, element := range node.RatingNode.Elements {
+				nodeLines = append(nodeLines, telegramLine{
+					text: telegramEscaper.Replace(fmt.Sprintf("• %s: %d/10 ⭐", element.Label, element.Value)),
+				})
+			}
+		default:
+			loggerFrom(ctx).WarnContext(ctx, "unknown node type", "nodeType", node.NodeType, "adapter", "telegram")
+			continue
+		}
+		lines = append(lines, nodeLines...)
+	}
+
+	chunks := telegramChunks(lines, telegramMessageLimit)
+	// telegram rejects messages without text
+	if len(chunks) == 1 && strings.TrimSpace(chunks[0]) == "" {
+		fallback := "New submission"
+		for _, text := range []string{data.FormTranslation, data.LinkText} {
+			if strings.TrimSpace(text) != "" {
+				fallback = text
+				break
+			}
+		}
+		chunks[0] = telegramEscaper.Replace(fallback)
+	}
+	webhooks := make([]*Webhook, len(chunks))
+	for idx, chunk := range chunks {
+		message := telegramData{
+			ChatID:          cfg.ChatID,
+			MessageThreadID: cfg.MessageThreadID,
+			Text:            chunk,
+			ParseMode:       "MarkdownV2",
+		}
+		// the button goes below the last message, once everything has been read
+		if idx == len(chunks)-1 && data.LinkUrl != "" {
+			message.ReplyMarkup = &telegramReplyMarkup{
+				InlineKeyboard: [][]telegramInlineButton{
+					{
+						{
+							Text: data.LinkText,
+							URL:  data.LinkUrl,
+						},
+					},
+				},
+			}
+		}
+		webhooks[idx] = &Webhook{
+			Data:    message,
+			Headers: nil,
+		}
+	}
+	webhooks[0].Followups = webhooks[1:]
+
+	return webhooks[0], nil
+}
@@ -0,0 +1,186 @@
+package integrations
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// telegramMarkup returns the runes of text that act as markup, i.e. are not
+// escaped by a preceding backslash, and whether text ends in a dangling
+// escape backslash.
+func telegramMarkup(text string) (string, bool) {
+	markup := &strings.Builder{}
+	runes := []rune(text)
+	for idx := 0; idx < len(runes); idx++ {
+		if runes[idx] == '\\' {
+			if idx == len(runes)-1 {
+				return markup.String(), true
+			}
+			idx++
+			continue
+		}
+		if strings.ContainsRune("_*[]()~`>#+-=|{}.!", runes[idx]) {
+			markup.WriteRune(runes[idx])
+		}
+	}
+	return markup.String(), false
+}
+
+func TestTelegramEscaper(t *testing.T) {
+	for _, reserved := range "_*[]()~`>#+-=|{}.!" {
+		input := string(reserved)
+		if got, want := telegramEscaper.Replace(input), "\\"+input; got != want {
+			t.Errorf("escape %q = %q, want %q", input, got, want)
+		}
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"plain text", "plain text"},
+		{"\\", "\\\\"},
+		{"\\.", "\\\\\\."},
+		{"a_b*c", "a\\_b\\*c"},
+		{"[link](https://example.com)", "\\[link\\]\\(https://example\\.com\\)"},
+		{"1 + 1 = 2!", "1 \\+ 1 \\= 2\\!"},
+		{"ünïcödé ⭐", "ünïcödé ⭐"},
+	}
+	for _, test := range tests {
+		if got := telegramEscaper.Replace(test.input); got != test.want {
+			t.Errorf("escape %q = %q, want %q", test.input, got, test.want)
+		}
+	}
+}
+
+func TestTelegramSplit(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+	}{
+		{"short", "hello", 10},
+		{"escapes", telegramEscaper.Replace(strings.Repeat("a.", 50)), 5},
+		{"only escapes", telegramEscaper.Replace(strings.Repeat(".", 50)), 7},
+		{"backslashes", telegramEscaper.Replace(strings.Repeat("\\", 50)), 3},
+		{"unicode", telegramEscaper.Replace(strings.Repeat("ä!⭐", 40)), 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pieces := telegramSplit(test.text, test.limit)
+			if joined := strings.Join(pieces, ""); joined != test.text {
+				t.Fatalf("pieces join to %q, want %q", joined, test.text)
+			}
+			for _, piece := range pieces {
+				if size := utf8.RuneCountInString(piece); size > test.limit {
+					t.Errorf("piece %q has %d runes, limit %d", piece, size, test.limit)
+				}
+				if markup, dangling := telegramMarkup(piece); dangling || markup != "" {
+					t.Errorf("piece %q cuts an escape pair", piece)
+				}
+			}
+		})
+	}
+}
+
+func TestTelegramChunks(t *testing.T) {
+	long := telegramEscaper.Replace(strings.Repeat("answer. ", 1500))
+	lines := []telegramLine{
+		{text: telegramEscaper.Replace("Title *with* markup"), bold: true},
+		{},
+		{text: long, bold: true},
+		{text: long},
+	}
+	for idx := 0; idx < 500; idx++ {
+		lines = append(lines, telegramLine{text: telegramEscaper.Replace("Question #" + strings.Repeat("!", idx%20)), bold: idx%2 == 0})
+	}
+
+	for _, limit := range []int{64, telegramMessageLimit} {
+		chunks := telegramChunks(lines, limit)
+		if len(chunks) < 2 {
+			t.Fatalf("limit %d: got %d chunks, want the text split", limit, len(chunks))
+		}
+		for _, chunk := range chunks {
+			if size := utf8.RuneCountInString(chunk); size > limit {
+				t.Errorf("limit %d: chunk has %d runes", limit, size)
+			}
+			markup, dangling := telegramMarkup(chunk)
+			if dangling {
+				t.Errorf("limit %d: chunk ends in a dangling escape: %q", limit, chunk)
+			}
+			if strings.Trim(markup, "*") != "" {
+				t.Errorf("limit %d: chunk has unescaped markup %q", limit, markup)
+			}
+			for _, line := range strings.Split(chunk, "\n") {
+				if markup, _ := telegramMarkup(line); len(markup)%2 != 0 {
+					t.Errorf("limit %d: unbalanced bold in line %q", limit, line)
+				}
+			}
+		}
+	}
+}
+
+func TestTelegramFollowups(t *testing.T) {
+	data := &InputFormFinished{
+		Title:    "New *submission*",
+		LinkText: "Open",
+		LinkUrl:  "https://example.com/submission",
+	}
+	for idx := 0; idx < 200; idx++ {
+		data.Nodes = append(data.Nodes, InputFormFinishedNode{
+			NodeType:        1,
+			NodeTranslation: "Which options?",
+			SelectNode: InputSelectNode{
+				Selected: []string{strings.Repeat("option_(1). ", 10)},
+			},
+		})
+	}
+
+	webhook, err := telegram(context.Background(), data, &TelegramConfig{ChatID: "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(webhook.Followups) == 0 {
+		t.Fatal("got no followups for a message above the limit")
+	}
+
+	messages := append([]*Webhook{webhook}, webhook.Followups...)
+	for idx, message := range messages {
+		text := message.Data.(telegramData).Text
+		if size := utf8.RuneCountInString(text); size > telegramMessageLimit {
+			t.Errorf("message %d has %d runes", idx, size)
+		}
+		markup, dangling := telegramMarkup(text)
+		if dangling || len(markup)%2 != 0 {
+			t.Errorf("message %d has unbalanced markup", idx)
+		}
+		hasButton := message.Data.(telegramData).ReplyMarkup != nil
+		if hasButton != (idx == len(messages)-1) {
+			t.Errorf("message %d: button %t, want it on the last message only", idx, hasButton)
+		}
+	}
+}
+
+func TestTelegramEmptyText(t *testing.T) {
+	tests := []struct {
+		data *InputFormFinished
+		want string
+	}{
+		{&InputFormFinished{}, "New submission"},
+		{&InputFormFinished{LinkText: "Open submission"}, "Open submission"},
+		{&InputFormFinished{FormTranslation: "Feedback", LinkText: "Open"}, "Feedback"},
+		{&InputFormFinished{Title: "   "}, "New submission"},
+	}
+	for _, test := range tests {
+		webhook, err := telegram(context.Background(), test.data, &TelegramConfig{ChatID: "42"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if text := webhook.Data.(telegramData).Text; text != test.want {
+			t.Errorf("text = %q, want %q", text, test.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grokify/go-adaptivecards"
+)
+
+// WebexConfig configures the Webex adapter, which sends a markdown message
+// unless AdaptiveCard is set.
+type WebexConfig struct {
+	AdaptiveCard bool
+}
+
+type webexData struct {
+	Markdown    string                `json:"markdown"`
+	Attachments []webexDataAttachment `json:"attachments,omitempty"`
+}
+
+type webexDataAttachment struct {
+	ContentType string                     `json:"contentType"`
+	Content     adaptivecards.AdaptiveCard `json:"content"`
+}
+
+func webex(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, _ := config.(*WebexConfig)
+	title := fmt.Sprintf("%s [%s](%s)", data.Title, data.LinkText, data.LinkUrl)
+
+	if cfg != nil && cfg.AdaptiveCard {
+		card := teamsCard(ctx, data, "webex")
+		card.Schema = "" // $ sign in $schema struct tag trips convoy up
+
+		// the markdown is only shown by clients that cannot render cards
+		return &Webhook{
+			Data: webexData{
+				Markdown: title,
+				Attachments: []webexDataAttachment{
+					{
+						ContentType: "application/vnd.microsoft.card.adaptive",
+						Content:     *card,
+					},
+				},
+			},
+			Headers: nil,
+		}, nil
+	}
+
+	return &Webhook{
+		Data: webexData{
+			Markdown: title + "\n\n" + markdownMessage(ctx, data, "webex"),
+		},
+		Headers: nil,
+	}, nil
+}
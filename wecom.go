@@ -0,0 +1,57 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+)
+
+// wecomMarkdownLimit is the maximum size of a markdown message in bytes.
+const wecomMarkdownLimit = 4096
+
+type wecomData struct {
+	MsgType  string        `json:"msgtype"`
+	Markdown wecomMarkdown `json:"markdown"`
+}
+
+type wecomMarkdown struct {
+	Content string `json:"content"`
+}
+
+// truncateBytes cuts text to at most limit bytes without splitting a rune.
+func truncateBytes(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	if limit < 0 {
+		return ""
+	}
+	for limit > 0 && !utf8.RuneStart(text[limit]) {
+		limit--
+	}
+	return text[:limit]
+}
+
+func wecom(ctx context.Context, data *InputFormFinished, _ interface{}) (*Webhook, error) {
+	link := fmt.Sprintf("[%s](%s)", data.LinkText, data.LinkUrl)
+	content := chineseMarkdown(ctx, data, "wecom") + "\n\n" + link
+
+	if len(content) > wecomMarkdownLimit {
+		suffix := "\n\n……" + link
+		if len(suffix) > wecomMarkdownLimit/2 {
+			// links too long to keep are dropped with the rest
+			suffix = "\n\n……"
+		}
+		content = truncateBytes(content, wecomMarkdownLimit-len(suffix)) + suffix
+	}
+
+	return &Webhook{
+		Data: wecomData{
+			MsgType: "markdown",
+			Markdown: wecomMarkdown{
+				Content: content,
+			},
+		},
+		Headers: nil,
+	}, nil
+}
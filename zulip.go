@@ -0,0 +1,47 @@
+package integrations
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// zulipTopicLimit is the maximum length of a Zulip topic name in characters.
+const zulipTopicLimit = 60
+
+// ZulipConfig configures the Zulip adapter, which posts to the
+// /api/v1/messages endpoint as a bot user.
+type ZulipConfig struct {
+	Stream   string
+	BotEmail string
+	APIKey   string
+}
+
+func zulip(ctx context.Context, data *InputFormFinished, config interface{}) (*Webhook, error) {
+	cfg, ok := config.(*ZulipConfig)
+	if !ok || cfg == nil || cfg.Stream == "" {
+		return nil, errors.New("zulip stream not configured")
+	}
+	topic := data.FormTranslation
+	if topic == "" {
+		topic = data.Title
+	}
+
+	headers := map[string][]string{}
+	if cfg.BotEmail != "" && cfg.APIKey != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(cfg.BotEmail + ":" + cfg.APIKey))
+		headers["Authorization"] = []string{"Basic " + credentials}
+	}
+
+	return &Webhook{
+		Data: url.Values{
+			"type":    {"stream"},
+			"to":      {cfg.Stream},
+			"topic":   {truncateRunes(topic, zulipTopicLimit)},
+			"content": {fmt.Sprintf("%s [%s](%s)\n\n%s", data.Title, data.LinkText, data.LinkUrl, markdownMessage(ctx, data, "zulip"))},
+		},
+		Headers: headers,
+	}, nil
+}